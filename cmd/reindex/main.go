@@ -0,0 +1,159 @@
+// Command reindex rebuilds derived search fields on stored videos.
+//
+//	go run ./cmd/reindex               # videos missing n-grams, normalized text or language
+//	go run ./cmd/reindex -all          # every video, e.g. after changing the tokenizer
+//	go run ./cmd/reindex -suggest      # rebuild the autocomplete index in Redis
+//	go run ./cmd/reindex -vocabulary   # rebuild the spelling vocabulary in Redis
+//	go run ./cmd/reindex -synonyms     # rewrite synonym markers (search_terms)
+//	go run ./cmd/reindex -transcripts  # renormalize transcript text for transcript search
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+	"fampay-youtube-api/pkg/database"
+	"fampay-youtube-api/pkg/redis"
+)
+
+func main() {
+	all := flag.Bool("all", false, "rebuild every video instead of only those missing search fields")
+	suggest := flag.Bool("suggest", false, "rebuild autocomplete suggestions from stored videos")
+	vocabulary := flag.Bool("vocabulary", false, "rebuild the spelling vocabulary from stored videos")
+	synonyms := flag.Bool("synonyms", false, "rewrite synonym markers for the current dictionary")
+	transcripts := flag.Bool("transcripts", false, "renormalize stored transcript text")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := database.NewMongoDB(cfg.MongoDB)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer db.Client().Disconnect(context.Background())
+
+	videoRepo := repository.NewVideoRepository(db)
+
+	if *suggest {
+		rebuildSuggestions(cfg, videoRepo)
+		return
+	}
+	if *vocabulary {
+		rebuildVocabulary(cfg, videoRepo)
+		return
+	}
+	if *synonyms {
+		rebuildSearchTerms(db, videoRepo)
+		return
+	}
+	if *transcripts {
+		renormalizeTranscripts(db)
+		return
+	}
+
+	start := time.Now()
+	updated, err := videoRepo.ReindexSearchFields(*all)
+	if err != nil {
+		log.Fatalf("❌ Reindex failed after %d videos: %v", updated, err)
+	}
+
+	log.Printf("✅ Rebuilt search fields on %d videos (took %v)", updated, time.Since(start))
+}
+
+// rebuildSuggestions clears the prefix sets and replays every stored video
+func rebuildSuggestions(cfg *config.Config, videoRepo *repository.VideoRepository) {
+	redisClient, err := redis.NewClient(cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	suggestService := services.NewSuggestService(redisClient, time.Duration(cfg.Search.SuggestHalfLife)*time.Hour)
+
+	start := time.Now()
+	if err := suggestService.Reset(ctx); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if err := suggestService.RecordSearchQueries(ctx, cfg.YouTube.SearchQueries); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	var indexed int
+	err = videoRepo.ForEach(func(video *models.Video) error {
+		indexed++
+		return suggestService.RecordVideo(ctx, video)
+	})
+	if err != nil {
+		log.Fatalf("❌ Suggestion rebuild failed after %d videos: %v", indexed, err)
+	}
+
+	log.Printf("✅ Rebuilt suggestions from %d videos (took %v)", indexed, time.Since(start))
+}
+
+// rebuildVocabulary clears the word counts and recounts every stored video
+func rebuildVocabulary(cfg *config.Config, videoRepo *repository.VideoRepository) {
+	redisClient, err := redis.NewClient(cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	spellingService := services.NewSpellingService(redisClient, time.Duration(cfg.Search.VocabularyReloadInterval)*time.Second)
+
+	start := time.Now()
+	if err := spellingService.Reset(ctx); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	var indexed int
+	err = videoRepo.ForEach(func(video *models.Video) error {
+		indexed++
+		_, err := spellingService.RecordVideo(ctx, video)
+		return err
+	})
+	if err != nil {
+		log.Fatalf("❌ Vocabulary rebuild failed after %d videos: %v", indexed, err)
+	}
+
+	log.Printf("✅ Rebuilt vocabulary from %d videos (took %v)", indexed, time.Since(start))
+}
+
+// rebuildSearchTerms rewrites search_terms for the enabled synonym groups
+func rebuildSearchTerms(db *mongo.Database, videoRepo *repository.VideoRepository) {
+	synonymService := services.NewSynonymService(repository.NewSynonymRepository(db), videoRepo)
+	if err := synonymService.Reload(); err != nil {
+		log.Fatalf("❌ Failed to load synonym dictionary: %v", err)
+	}
+
+	start := time.Now()
+	updated, err := videoRepo.ReindexSearchTerms(synonymService.Tokens)
+	if err != nil {
+		log.Fatalf("❌ Synonym reindex failed after %d videos: %v", updated, err)
+	}
+
+	log.Printf("✅ Rewrote synonym markers on %d videos (took %v)", updated, time.Since(start))
+}
+
+// renormalizeTranscripts rewrites the normalized text transcript search matches
+func renormalizeTranscripts(db *mongo.Database) {
+	start := time.Now()
+	updated, err := repository.NewTranscriptRepository(db).ReindexNormalizedText()
+	if err != nil {
+		log.Fatalf("❌ Transcript reindex failed after %d segments: %v", updated, err)
+	}
+
+	log.Printf("✅ Renormalized %d transcript segments (took %v)", updated, time.Since(start))
+}
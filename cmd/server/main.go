@@ -15,6 +15,7 @@ import (
 	"fampay-youtube-api/internal/api/routes"
 	"fampay-youtube-api/internal/config"
 	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
 	"fampay-youtube-api/internal/worker"
 	"fampay-youtube-api/pkg/database"
 	"fampay-youtube-api/pkg/redis"
@@ -39,18 +40,125 @@ func main() {
 		log.Fatal("Failed to connect to Redis:", err)
 	}
 
-	// Initialize repository
+	// Initialize repositories
 	videoRepo := repository.NewVideoRepository(db)
+	ruleRepo := repository.NewFilterRuleRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	transcriptRepo := repository.NewTranscriptRepository(db)
+	channelRepo := repository.NewChannelRepository(db)
+	trendingRepo := repository.NewTrendingRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	synonymRepo := repository.NewSynonymRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
 
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
-	// Initialize router
-	router := routes.SetupRouter(videoRepo, redisClient, cfg)
+	// Initialize background worker
+	filterEngine := services.NewFilterEngine(ruleRepo)
+
+	// Synonym dictionary; markers of videos stored before the latest edits are brought up to date
+	synonymService := services.NewSynonymService(synonymRepo, videoRepo)
+	if err := synonymService.Reload(); err != nil {
+		log.Printf("⚠️ Failed to load synonym dictionary: %v", err)
+	}
+	synonymService.ReindexVideos()
+
+	// Fields added after older videos and transcripts were stored
+	go func() {
+		if marked, err := videoRepo.BackfillEnrichedFlag(); err != nil {
+			log.Printf("⚠️ %v", err)
+		} else if marked > 0 {
+			log.Printf("🛡️ Marked %d previously enriched videos for safety=strict", marked)
+		}
+
+		if backfilled, err := transcriptRepo.BackfillVideoFields(); err != nil {
+			log.Printf("⚠️ %v", err)
+		} else if backfilled > 0 {
+			log.Printf("📝 Backfilled video fields on %d transcript segments", backfilled)
+		}
+
+		updated, err := videoRepo.ReindexSearchFields(false)
+		if err != nil {
+			log.Printf("⚠️ Search field backfill failed after %d videos: %v", updated, err)
+			return
+		}
+		if updated > 0 {
+			log.Printf("🔤 Backfilled search fields on %d videos", updated)
+		}
+	}()
+
+	// One YouTube client for every worker and the live search endpoint, so
+	// they all rotate away from an exhausted API key together
+	youtubeService := services.NewYouTubeServiceFromConfig(cfg.YouTube)
+
+	videoFetcher := worker.NewVideoFetcher(videoRepo, commentRepo, transcriptRepo, categoryRepo, filterEngine, synonymService, youtubeService, cfg.YouTube)
 
-	// Start background worker
-	videoFetcher := worker.NewVideoFetcher(videoRepo, cfg.YouTube)
+	// Autocomplete index, updated as the fetcher stores videos
+	suggestService := services.NewSuggestService(redisClient, time.Duration(cfg.Search.SuggestHalfLife)*time.Hour)
+	if err := suggestService.RecordSearchQueries(context.Background(), cfg.YouTube.SearchQueries); err != nil {
+		log.Printf("⚠️ Failed to index search query suggestions: %v", err)
+	}
+	videoFetcher.AddListener(suggestService)
+
+	// "Did you mean" vocabulary from stored titles and descriptions
+	spellingService := services.NewSpellingService(redisClient, time.Duration(cfg.Search.VocabularyReloadInterval)*time.Second)
+	videoFetcher.AddListener(spellingService)
+
+	// Saved searches are evaluated against videos as they are stored
+	savedSearchMatcher := worker.NewSavedSearchMatcher(savedSearchRepo, videoRepo, synonymService, time.Duration(cfg.Search.SavedSearchInterval)*time.Second)
+	videoFetcher.AddListener(savedSearchMatcher)
+
+	// Signed webhooks for subscribers to newly stored videos
+	webhookDispatcher := worker.NewWebhookDispatcher(webhookRepo, cfg.Webhooks)
+	if err := webhookDispatcher.Reload(); err != nil {
+		log.Printf("⚠️ Failed to load webhooks: %v", err)
+	}
+	videoFetcher.AddListener(webhookDispatcher)
+
+	// Live feed of new videos, relayed between replicas through Redis pub/sub
+	videoBroadcaster := services.NewVideoBroadcaster(redisClient, cfg.Stream.MaxClients, cfg.Stream.BufferSize)
+	videoFetcher.AddListener(videoBroadcaster)
+
+	// Initialize router
+	router := routes.SetupRouter(routes.Deps{
+		Config:            cfg,
+		VideoRepo:         videoRepo,
+		RuleRepo:          ruleRepo,
+		CommentRepo:       commentRepo,
+		ChannelRepo:       channelRepo,
+		TrendingRepo:      trendingRepo,
+		CategoryRepo:      categoryRepo,
+		SynonymRepo:       synonymRepo,
+		SavedSearchRepo:   savedSearchRepo,
+		WebhookRepo:       webhookRepo,
+		SuggestService:    suggestService,
+		SpellingService:   spellingService,
+		SynonymService:    synonymService,
+		YouTubeService:    youtubeService,
+		VideoBroadcaster:  videoBroadcaster,
+		VideoFetcher:      videoFetcher,
+		WebhookDispatcher: webhookDispatcher,
+	})
+
+	// Start background workers
 	go videoFetcher.Start()
+	go savedSearchMatcher.Start()
+	go webhookDispatcher.Start()
+	go videoBroadcaster.Start()
+
+	broadcastTracker := worker.NewBroadcastTracker(videoRepo, youtubeService, cfg.YouTube)
+	go broadcastTracker.Start()
+
+	channelRefresher := worker.NewChannelRefresher(channelRepo, youtubeService, cfg.YouTube)
+	go channelRefresher.Start()
+
+	trendingFetcher := worker.NewTrendingFetcher(trendingRepo, youtubeService, cfg.YouTube)
+	go trendingFetcher.Start()
+
+	categoryRefresher := worker.NewCategoryRefresher(categoryRepo, videoRepo, youtubeService, cfg.YouTube)
+	go categoryRefresher.Start()
 
 	// Start server
 	srv := &http.Server{
@@ -74,6 +182,9 @@ func main() {
 
 	log.Println("Shutting down server...")
 
+	// Close live feed streams, which would otherwise hold the shutdown open
+	videoBroadcaster.Stop()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
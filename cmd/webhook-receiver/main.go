@@ -0,0 +1,102 @@
+// Command webhook-receiver is a local endpoint for testing webhooks. It
+// verifies each request's signature and prints the event.
+//
+//	go run ./cmd/webhook-receiver -secret whsec_...            # listen on :9000
+//	go run ./cmd/webhook-receiver -secret whsec_... -status 500 # fail every request to exercise retries
+//	go run ./cmd/webhook-receiver -secret whsec_... -fail-rate 0.5
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"fampay-youtube-api/internal/services"
+)
+
+type event struct {
+	ID    string `json:"id"`
+	Event string `json:"event"`
+	Data  struct {
+		VideoID      string `json:"video_id"`
+		Title        string `json:"title"`
+		ChannelTitle string `json:"channel_title"`
+		SearchQuery  string `json:"search_query"`
+	} `json:"data"`
+}
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	secret := flag.String("secret", "", "webhook secret (signatures are not checked when empty)")
+	status := flag.Int("status", http.StatusOK, "status code returned for valid requests")
+	failRate := flag.Float64("fail-rate", 0, "fraction of valid requests answered with 503 instead")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "maximum age of a signed request")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Println("⚠️ No -secret given, signatures are not verified")
+	}
+
+	var mutex sync.Mutex
+	seen := make(map[string]int) // Delivery ID -> requests received
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if *secret != "" {
+			err := services.VerifyWebhookSignature(*secret,
+				r.Header.Get(services.WebhookSignatureHeader),
+				r.Header.Get(services.WebhookTimestampHeader),
+				body, *tolerance)
+			if err != nil {
+				log.Printf("❌ Rejected delivery %s: %v", r.Header.Get(services.WebhookDeliveryHeader), err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var e event
+		if err := json.Unmarshal(body, &e); err != nil {
+			log.Printf("❌ Invalid payload: %v", err)
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		mutex.Lock()
+		seen[e.ID]++
+		attempt := seen[e.ID]
+		mutex.Unlock()
+
+		code := *status
+		if *failRate > 0 && rand.Float64() < *failRate {
+			code = http.StatusServiceUnavailable
+		}
+
+		switch e.Event {
+		case "video.created":
+			log.Printf("📥 %s %s (attempt %d, %d): %s [%s] via %q",
+				e.Event, e.ID, attempt, code, e.Data.Title, e.Data.ChannelTitle, e.Data.SearchQuery)
+		default:
+			log.Printf("📥 %s %s (attempt %d, %d)", e.Event, e.ID, attempt, code)
+		}
+
+		w.WriteHeader(code)
+	})
+
+	log.Printf("🎧 Webhook receiver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
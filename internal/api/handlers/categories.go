@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/repository"
+)
+
+type CategoryHandler struct {
+	categoryRepo  *repository.CategoryRepository
+	defaultRegion string
+}
+
+func NewCategoryHandler(categoryRepo *repository.CategoryRepository, defaultRegion string) *CategoryHandler {
+	return &CategoryHandler{
+		categoryRepo:  categoryRepo,
+		defaultRegion: defaultRegion,
+	}
+}
+
+// GetCategories - Video category catalog of a region
+func (ch *CategoryHandler) GetCategories(c *gin.Context) {
+	region := strings.ToUpper(c.DefaultQuery("region", ch.defaultRegion))
+
+	categories, err := ch.categoryRepo.ListByRegion(region)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch video categories",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(categories) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No video categories for this region (check CATEGORY_REGIONS)",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"region":     region,
+		"categories": categories,
+	})
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/repository"
+)
+
+type ChannelHandler struct {
+	channelRepo *repository.ChannelRepository
+	videoRepo   *repository.VideoRepository
+}
+
+func NewChannelHandler(channelRepo *repository.ChannelRepository, videoRepo *repository.VideoRepository) *ChannelHandler {
+	return &ChannelHandler{
+		channelRepo: channelRepo,
+		videoRepo:   videoRepo,
+	}
+}
+
+// GetChannels - Stored channels (paginated, sorted by subscribers by default)
+func (ch *ChannelHandler) GetChannels(c *gin.Context) {
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+	sortBy := c.DefaultQuery("sort", "subscribers") // subscribers, name, videos, views
+
+	channels, pageInfo, err := ch.channelRepo.GetPaginated(pagination, sortBy)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch channels",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response := newPageResponse(c, channels, pagination, pageInfo, "/api/channels")
+	c.JSON(http.StatusOK, response)
+}
+
+func (ch *ChannelHandler) GetChannel(c *gin.Context) {
+	channel, err := ch.channelRepo.GetByChannelID(c.Param("channel_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch channel",
+			"details": err.Error(),
+		})
+		return
+	}
+	if channel == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Channel not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// GetChannelVideos - Stored videos of a channel, with the same sorting and filters as /api/videos
+func (ch *ChannelHandler) GetChannelVideos(c *gin.Context) {
+	channelID := c.Param("channel_id")
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+	sortBy := c.DefaultQuery("sort", "latest")
+
+	videoFilter, err := parseVideoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	videoFilter.ChannelIDs = []string{channelID}
+
+	videos, pageInfo, err := ch.videoRepo.GetPaginated(pagination, sortBy, videoFilter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch channel videos",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	path := fmt.Sprintf("/api/channels/%s/videos", channelID)
+	response := newPageResponse(c, videos, pagination, pageInfo, path)
+	c.JSON(http.StatusOK, response)
+}
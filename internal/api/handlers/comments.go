@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/repository"
+)
+
+type CommentHandler struct {
+	commentRepo *repository.CommentRepository
+	videoRepo   *repository.VideoRepository
+}
+
+func NewCommentHandler(commentRepo *repository.CommentRepository, videoRepo *repository.VideoRepository) *CommentHandler {
+	return &CommentHandler{
+		commentRepo: commentRepo,
+		videoRepo:   videoRepo,
+	}
+}
+
+// GetComments - Top comments of a stored video (paginated, relevance order)
+func (ch *CommentHandler) GetComments(c *gin.Context) {
+	videoID := c.Param("video_id")
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+
+	video, err := ch.videoRepo.GetByVideoID(videoID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch video",
+			"details": err.Error(),
+		})
+		return
+	}
+	if video == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Video not found"})
+		return
+	}
+
+	comments, pageInfo, err := ch.commentRepo.GetByVideoID(videoID, pagination)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch comments",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	path := fmt.Sprintf("/api/videos/%s/comments", videoID)
+	response := newPageResponse(c, comments, pagination, pageInfo, path)
+	c.JSON(http.StatusOK, response)
+}
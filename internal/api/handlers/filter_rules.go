@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+type FilterRuleHandler struct {
+	ruleRepo *repository.FilterRuleRepository
+}
+
+func NewFilterRuleHandler(ruleRepo *repository.FilterRuleRepository) *FilterRuleHandler {
+	return &FilterRuleHandler{
+		ruleRepo: ruleRepo,
+	}
+}
+
+type filterRuleRequest struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Query   string   `json:"query"`
+	Fields  []string `json:"fields"`
+	Values  []string `json:"values"`
+	Enabled *bool    `json:"enabled"`
+}
+
+func (req filterRuleRequest) toRule() *models.FilterRule {
+	rule := &models.FilterRule{
+		Name:    strings.TrimSpace(req.Name),
+		Type:    req.Type,
+		Query:   strings.TrimSpace(req.Query),
+		Fields:  req.Fields,
+		Values:  req.Values,
+		Enabled: true,
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	return rule
+}
+
+// ListRules - All ingestion filter rules
+func (fh *FilterRuleHandler) ListRules(c *gin.Context) {
+	rules, err := fh.ruleRepo.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch filter rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+func (fh *FilterRuleHandler) GetRule(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	rule, err := fh.ruleRepo.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	if rule == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter rule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (fh *FilterRuleHandler) CreateRule(c *gin.Context) {
+	var req filterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule := req.toRule()
+	if err := services.ValidateFilterRule(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := fh.ruleRepo.Create(rule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (fh *FilterRuleHandler) UpdateRule(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req filterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule := req.toRule()
+	rule.ID = id
+	if err := services.ValidateFilterRule(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	found, err := fh.ruleRepo.Update(rule)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter rule not found"})
+		return
+	}
+
+	updated, err := fh.ruleRepo.GetByID(id)
+	if err != nil || updated == nil {
+		c.JSON(http.StatusOK, rule)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (fh *FilterRuleHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	found, err := fh.ruleRepo.Delete(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to delete filter rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter rule not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRejections - Videos dropped by the ingestion filter and the rule that rejected them
+func (fh *FilterRuleHandler) ListRejections(c *gin.Context) {
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+
+	var ruleID *primitive.ObjectID
+	if hex := c.Query("rule_id"); hex != "" {
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule_id"})
+			return
+		}
+		ruleID = &id
+	}
+
+	rejections, pageInfo, err := fh.ruleRepo.GetRejections(ruleID, pagination)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch rejected videos",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response := newPageResponse(c, rejections, pagination, pageInfo, "/api/admin/rejections")
+	c.JSON(http.StatusOK, response)
+}
+
+// parseObjectID reads a hex ObjectID path parameter, responding 400 when malformed
+func parseObjectID(c *gin.Context, param string) (primitive.ObjectID, bool) {
+	id, err := primitive.ObjectIDFromHex(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+		return primitive.NilObjectID, false
+	}
+	return id, true
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// parseVideoFilter reads the optional filters shared by /api/videos and /api/videos/search
+func parseVideoFilter(c *gin.Context) (repository.VideoFilter, error) {
+	return repository.ParseVideoFilter(c.Request.URL.Query())
+}
+
+// parseFacets reads the comma-separated ?facets= list of /api/videos/search.
+// Without the parameter only the category facet is returned; an empty value
+// disables facets.
+func parseFacets(c *gin.Context) ([]string, error) {
+	value, ok := c.GetQuery("facets")
+	if !ok {
+		return []string{models.FacetCategory}, nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !models.ValidFacet(name) {
+			return nil, fmt.Errorf("invalid facet %q (expected channel, search_query, published_day or category)", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// parseVideoEventFilter reads the query and channel_id lists of a live feed request
+func parseVideoEventFilter(c *gin.Context) (services.VideoEventFilter, error) {
+	values := c.Request.URL.Query()
+
+	var filter services.VideoEventFilter
+	var err error
+	if filter.Queries, err = repository.ParseListParam(values, "query"); err != nil {
+		return filter, err
+	}
+	if filter.ChannelIDs, err = repository.ParseListParam(values, "channel_id"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestParseFacets(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []string
+		wantErr string
+	}{
+		{name: "default", query: "", want: []string{models.FacetCategory}},
+		{name: "disabled", query: "facets=", want: nil},
+		{name: "list", query: "facets=channel,+published_day", want: []string{models.FacetChannel, models.FacetPublishedDay}},
+		{name: "duplicates and blanks", query: "facets=channel,,channel,search_query", want: []string{models.FacetChannel, models.FacetSearchQuery}},
+		{name: "unknown facet", query: "facets=channel,language", wantErr: `invalid facet "language"`},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/videos/search?q=cricket&"+tt.query, nil)
+
+			got, err := parseFacets(c)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseFacets() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFacets() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFacets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
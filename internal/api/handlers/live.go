@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+)
+
+type LiveHandler struct {
+	videoRepo     *repository.VideoRepository
+	searchQueries []string
+}
+
+func NewLiveHandler(videoRepo *repository.VideoRepository, searchQueries []string) *LiveHandler {
+	return &LiveHandler{
+		videoRepo:     videoRepo,
+		searchQueries: searchQueries,
+	}
+}
+
+type liveQueryResponse struct {
+	Query    string         `json:"query"`
+	Live     []models.Video `json:"live"`
+	Upcoming []models.Video `json:"upcoming"`
+}
+
+// GetLive - Broadcasts that are live now and upcoming, grouped per search query
+func (lh *LiveHandler) GetLive(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	queries := lh.searchQueries
+	if query := strings.TrimSpace(c.Query("query")); query != "" {
+		queries = []string{query}
+	}
+
+	results := make([]liveQueryResponse, 0, len(queries))
+	for _, query := range queries {
+		live, err := lh.videoRepo.GetBroadcasts(models.BroadcastLive, query, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to fetch live broadcasts",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		upcoming, err := lh.videoRepo.GetBroadcasts(models.BroadcastUpcoming, query, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to fetch upcoming broadcasts",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		results = append(results, liveQueryResponse{
+			Query:    query,
+			Live:     live,
+			Upcoming: upcoming,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queries": results})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/utils"
+)
+
+// parsePagination reads page and page_size with the same defaults and limits as the video endpoints
+func parsePagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "12"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize > 50 {
+		pageSize = 50
+	}
+	if pageSize < 1 {
+		pageSize = 12
+	}
+
+	return page, pageSize
+}
+
+// parsePageRequest reads page/page_size or an opaque ?cursor= token from
+// next_cursor/previous_cursor of an earlier response. Responds 400 and
+// returns false for malformed cursors.
+func parsePageRequest(c *gin.Context) (repository.Pagination, bool) {
+	page, pageSize := parsePagination(c)
+	pagination := repository.Pagination{Page: page, PageSize: pageSize}
+
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := repository.DecodeCursor(token)
+		if err != nil {
+			respondInvalidCursor(c)
+			return pagination, false
+		}
+		pagination.Cursor = cursor
+	}
+
+	return pagination, true
+}
+
+func respondInvalidCursor(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": "Invalid cursor (cursors are only valid for the sort they were issued for, and not for sort=relevance or fuzzy search)",
+	})
+}
+
+// newPageResponse builds the response envelope: page-number links for page
+// requests and cursor links for cursor requests. Both include the cursors.
+func newPageResponse(c *gin.Context, data interface{}, pagination repository.Pagination, info *repository.PageInfo, path string) *utils.PaginatedResponse {
+	if pagination.Cursor != nil {
+		return utils.NewCursorPaginatedResponse(data, path, c.Request.URL.Query(), info.NextCursor, info.PreviousCursor)
+	}
+
+	var total int64
+	if info.Total != nil {
+		total = *info.Total
+	}
+	response := utils.NewPaginatedResponseForQuery(data, total, pagination.Page, pagination.PageSize, path, c.Request.URL.Query())
+	response.NextCursor = info.NextCursor
+	response.PreviousCursor = info.PreviousCursor
+	return response
+}
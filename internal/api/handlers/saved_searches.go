@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+const (
+	defaultNewMatchesLimit = 100
+	maxNewMatchesLimit     = 500
+)
+
+type SavedSearchHandler struct {
+	savedSearchRepo *repository.SavedSearchRepository
+	videoRepo       *repository.VideoRepository
+}
+
+func NewSavedSearchHandler(savedSearchRepo *repository.SavedSearchRepository, videoRepo *repository.VideoRepository) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchRepo: savedSearchRepo,
+		videoRepo:       videoRepo,
+	}
+}
+
+type savedSearchRequest struct {
+	Name    string            `json:"name"`
+	Query   string            `json:"query"`
+	Filters map[string]string `json:"filters"`
+	Sort    string            `json:"sort"`
+}
+
+func (req savedSearchRequest) toSavedSearch() *models.SavedSearch {
+	return &models.SavedSearch{
+		Name:    strings.TrimSpace(req.Name),
+		Query:   strings.TrimSpace(req.Query),
+		Filters: req.Filters,
+		Sort:    req.Sort,
+	}
+}
+
+// ListSavedSearches - All saved searches
+func (sh *SavedSearchHandler) ListSavedSearches(c *gin.Context) {
+	savedSearches, err := sh.savedSearchRepo.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch saved searches",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"saved_searches": savedSearches})
+}
+
+// GetSavedSearch - A saved search with the number of matches since the last check
+func (sh *SavedSearchHandler) GetSavedSearch(c *gin.Context) {
+	savedSearch, ok := sh.loadSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	count, err := sh.savedSearchRepo.CountNewMatches(savedSearch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to count new matches",
+			"details": err.Error(),
+		})
+		return
+	}
+	savedSearch.NewMatches = &count
+
+	c.JSON(http.StatusOK, savedSearch)
+}
+
+func (sh *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	var req savedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	savedSearch := req.toSavedSearch()
+	if err := services.ValidateSavedSearch(savedSearch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid saved search",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := sh.savedSearchRepo.Create(savedSearch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create saved search",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedSearch)
+}
+
+func (sh *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req savedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	savedSearch := req.toSavedSearch()
+	savedSearch.ID = id
+	if err := services.ValidateSavedSearch(savedSearch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid saved search",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	found, err := sh.savedSearchRepo.Update(savedSearch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update saved search",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	updated, err := sh.savedSearchRepo.GetByID(id)
+	if err != nil || updated == nil {
+		c.JSON(http.StatusOK, savedSearch)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (sh *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	found, err := sh.savedSearchRepo.Delete(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to delete saved search",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetNewMatches - Videos matched since the last check, which this call
+// advances (?peek=true leaves it unchanged). Up to ?limit= matches are
+// returned; has_more means calling again returns the rest.
+func (sh *SavedSearchHandler) GetNewMatches(c *gin.Context) {
+	savedSearch, ok := sh.loadSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultNewMatchesLimit)))
+	if err != nil || limit < 1 || limit > maxNewMatchesLimit {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "limit must be between 1 and " + strconv.Itoa(maxNewMatchesLimit),
+		})
+		return
+	}
+
+	matches, hasMore, err := sh.savedSearchRepo.NewMatches(savedSearch, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch new matches",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	videoIDs := make([]primitive.ObjectID, 0, len(matches))
+	for _, match := range matches {
+		videoIDs = append(videoIDs, match.VideoID)
+	}
+	videos, err := sh.videoRepo.GetByIDs(videoIDs, savedSearch.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch matched videos",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Advance to the last returned match so the rest stays new
+	previousCheck := savedSearch.LastCheckedAt
+	if len(matches) > 0 && c.Query("peek") != "true" {
+		checkedAt := matches[len(matches)-1].MatchedAt
+		if err := sh.savedSearchRepo.MarkChecked(savedSearch.ID, checkedAt); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to update last check",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"saved_search_id": savedSearch.ID,
+		"since":           previousCheck.UTC().Format(time.RFC3339),
+		"count":           len(videos),
+		"has_more":        hasMore,
+		"results":         videos,
+	})
+}
+
+// loadSavedSearch resolves the :id path parameter, responding 400 or 404
+func (sh *SavedSearchHandler) loadSavedSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return nil, false
+	}
+
+	savedSearch, err := sh.savedSearchRepo.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch saved search",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+	if savedSearch == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return nil, false
+	}
+
+	return savedSearch, true
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/worker"
+)
+
+type ScheduleHandler struct {
+	videoFetcher *worker.VideoFetcher
+}
+
+func NewScheduleHandler(videoFetcher *worker.VideoFetcher) *ScheduleHandler {
+	return &ScheduleHandler{
+		videoFetcher: videoFetcher,
+	}
+}
+
+// GetSchedules - Fetch schedule and next run time of every search query
+func (sh *ScheduleHandler) GetSchedules(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"schedules": sh.videoFetcher.Schedules(),
+	})
+}
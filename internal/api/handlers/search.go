@@ -1,23 +1,33 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"fampay-youtube-api/internal/models"
 	"fampay-youtube-api/internal/repository"
-	"fampay-youtube-api/internal/utils"
+	"fampay-youtube-api/internal/search"
+	"fampay-youtube-api/internal/services"
 )
 
 type SearchHandler struct {
-	videoRepo *repository.VideoRepository
+	videoRepo       *repository.VideoRepository
+	spellingService *services.SpellingService
+	synonymService  *services.SynonymService
 }
 
-func NewSearchHandler(videoRepo *repository.VideoRepository) *SearchHandler {
+func NewSearchHandler(videoRepo *repository.VideoRepository, spellingService *services.SpellingService, synonymService *services.SynonymService) *SearchHandler {
 	return &SearchHandler{
-		videoRepo: videoRepo,
+		videoRepo:       videoRepo,
+		spellingService: spellingService,
+		synonymService:  synonymService,
 	}
 }
 
@@ -31,40 +41,227 @@ func (sh *SearchHandler) SearchVideos(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "12"))
 	sortBy := c.DefaultQuery("sort", "latest")
 
-	// Validate parameters
-	if page < 1 {
-		page = 1
-	}
-	if pageSize > 50 {
-		pageSize = 50
-	}
-	if pageSize < 1 {
-		pageSize = 12
+	// Page number or ?cursor= from an earlier response
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
 	}
 
 	// Validate sort parameter
 	validSorts := map[string]bool{
 		"latest": true, "oldest": true, "title": true, "channel": true,
+		"shortest": true, "longest": true, "relevance": true,
 	}
 	if !validSorts[sortBy] {
 		sortBy = "latest"
 	}
 
-	// Search videos using enhanced partial matching
-	videos, total, err := sh.videoRepo.Search(query, page, pageSize, sortBy)
+	// Optional filters (safety level, format, category)
+	videoFilter, err := parseVideoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Search transcripts instead of title and description
+	switch c.DefaultQuery("in", "title_description") {
+	case "title_description":
+	case "transcript":
+		results, pageInfo, err := sh.videoRepo.SearchTranscripts(query, pagination, sortBy, videoFilter)
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			respondInvalidCursor(c)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to search transcripts",
+				"details": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, newPageResponse(c, results, pagination, pageInfo, "/api/videos/search"))
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid in parameter (expected title_description or transcript)",
+		})
+		return
+	}
+
+	// Facet bucket counts returned next to the results (category by default)
+	facetNames, err := parseFacets(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Quoted phrases, -exclusions, OR and field qualifiers (channel:, title:, query:, before:, after:)
+	parsedQuery, err := search.ParseQuery(query)
+	if err != nil {
+		var syntaxErr *search.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    "Invalid search query: " + syntaxErr.Error(),
+				"position": syntaxErr.Pos,
+				"token":    syntaxErr.Token,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	parsedQuery = sh.expandSynonyms(parsedQuery)
+
+	// Typo-tolerant matching on request, or when the exact search finds
+	// nothing; the cursors of fuzzy pages keep later pages fuzzy
+	fuzzy := c.Query("fuzzy") == "true" || repository.IsFuzzyCursor(pagination.Cursor)
+
+	var videos []models.Video
+	var pageInfo *repository.PageInfo
+	var suggestion string
+	if !fuzzy {
+		videos, pageInfo, err = sh.videoRepo.Search(parsedQuery, pagination, sortBy, videoFilter)
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			respondInvalidCursor(c)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to search videos",
+				"details": err.Error(),
+			})
+			return
+		}
+		fuzzy = pageInfo.Total != nil && *pageInfo.Total == 0
+
+		// "Did you mean": offer a corrected query when it finds something
+		if fuzzy {
+			suggestion = sh.suggestCorrection(c.Request.Context(), parsedQuery, sortBy, videoFilter)
+		}
+	}
+
+	if fuzzy {
+		videos, pageInfo, err = sh.videoRepo.SearchFuzzy(parsedQuery, pagination, sortBy, videoFilter)
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			respondInvalidCursor(c)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to search videos",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	// Highlighted title, description fragments and best snippet per result
+	if c.DefaultQuery("highlight", "true") != "false" {
+		highlighter, err := parseHighlighter(c, parsedQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		for i := range videos {
+			videos[i].Highlight = &models.Highlight{
+				Title:       highlighter.Title(videos[i].Title),
+				Description: highlighter.Fragments(videos[i].Description),
+				Snippet:     highlighter.Snippet(videos[i].Description),
+			}
+		}
+	}
+
+	// Bucket counts per requested facet
+	facets, err := sh.videoRepo.SearchFacets(parsedQuery, videoFilter, fuzzy, facetNames)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to search videos",
+			"error":   "Failed to count search facets",
 			"details": err.Error(),
 		})
 		return
 	}
 
 	// Create paginated response
-	response := utils.NewPaginatedResponse(videos, total, page, pageSize)
+	response := newPageResponse(c, videos, pagination, pageInfo, "/api/videos/search")
+	if len(facets) > 0 {
+		response.Facets = facets
+	}
+	response.Fuzzy = fuzzy
+	response.Suggestion = suggestion
 	c.JSON(http.StatusOK, response)
 }
+
+// suggestCorrection returns the spelling-corrected query if it has results
+// with the same filters. Failures only cost the suggestion, so they are logged.
+func (sh *SearchHandler) suggestCorrection(ctx context.Context, query *search.Query, sortBy string, videoFilter repository.VideoFilter) string {
+	if sh.spellingService == nil {
+		return ""
+	}
+
+	corrected, changed, err := sh.spellingService.CorrectQuery(ctx, query)
+	if err != nil {
+		log.Printf("⚠️ Spelling correction failed: %v", err)
+		return ""
+	}
+	if !changed {
+		return ""
+	}
+
+	correctedQuery, err := search.ParseQuery(corrected)
+	if err != nil {
+		return ""
+	}
+	correctedQuery = sh.expandSynonyms(correctedQuery)
+
+	_, pageInfo, err := sh.videoRepo.Search(correctedQuery, repository.Pagination{Page: 1, PageSize: 1}, sortBy, videoFilter)
+	if err != nil {
+		log.Printf("⚠️ Failed to check corrected query %q: %v", corrected, err)
+		return ""
+	}
+	if pageInfo.Total == nil || *pageInfo.Total == 0 {
+		return ""
+	}
+	return corrected
+}
+
+// expandSynonyms lets terms match every spelling and script of their
+// synonym group ("kriket" also finds "cricket" and "क्रिकेट")
+func (sh *SearchHandler) expandSynonyms(query *search.Query) *search.Query {
+	if sh.synonymService == nil {
+		return query
+	}
+	return sh.synonymService.Expand(query)
+}
+
+// Longest accepted highlight tag
+const maxHighlightTagLength = 32
+
+// parseHighlighter reads highlight_pre_tag, highlight_post_tag and snippet_length
+func parseHighlighter(c *gin.Context, query *search.Query) (*search.Highlighter, error) {
+	preTag := c.DefaultQuery("highlight_pre_tag", search.DefaultPreTag)
+	postTag := c.DefaultQuery("highlight_post_tag", search.DefaultPostTag)
+	if len(preTag) > maxHighlightTagLength || len(postTag) > maxHighlightTagLength {
+		return nil, fmt.Errorf("highlight tags cannot be longer than %d characters", maxHighlightTagLength)
+	}
+
+	snippetLength := search.DefaultSnippetLength
+	if value := c.Query("snippet_length"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 40 || parsed > 1000 {
+			return nil, fmt.Errorf("invalid snippet_length %q (expected 40-1000 characters)", value)
+		}
+		snippetLength = parsed
+	}
+
+	return search.NewHighlighter(query, preTag, postTag, snippetLength), nil
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+const (
+	// Videos read per query when replaying after Last-Event-ID
+	streamReplayPageSize = 200
+
+	// Videos replayed on resume; clients further behind get a reset event
+	// and should refetch the list instead
+	maxStreamReplay = 2000
+
+	// Reconnect delay suggested to EventSource clients
+	streamRetryMillis = 3000
+)
+
+type StreamHandler struct {
+	videoRepo   *repository.VideoRepository
+	broadcaster *services.VideoBroadcaster
+	heartbeat   time.Duration
+}
+
+func NewStreamHandler(videoRepo *repository.VideoRepository, broadcaster *services.VideoBroadcaster, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		videoRepo:   videoRepo,
+		broadcaster: broadcaster,
+		heartbeat:   heartbeat,
+	}
+}
+
+// StreamVideos - Server-Sent Events feed of newly stored videos
+// (?query=cricket,football&channel_id=UCabc). Each "video" event's id is
+// the video's id; reconnecting with Last-Event-ID (or ?last_event_id=)
+// first replays the videos stored since.
+func (sh *StreamHandler) StreamVideos(c *gin.Context) {
+	filter, err := parseVideoEventFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// EventSource sends the header on reconnects; the parameter lets a new
+	// page pick up where a previous one stopped
+	lastEventHex := c.GetHeader("Last-Event-ID")
+	if lastEventHex == "" {
+		lastEventHex = c.Query("last_event_id")
+	}
+	var lastEventID *primitive.ObjectID
+	if lastEventHex != "" {
+		id, err := primitive.ObjectIDFromHex(lastEventHex)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+		lastEventID = &id
+	}
+
+	// Subscribe before replaying so nothing stored in between is missed
+	subscription, err := sh.broadcaster.Subscribe(filter)
+	if errors.Is(err, services.ErrTooManySubscribers) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many live feed clients, retry later"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to open live feed",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis); err != nil {
+		return
+	}
+	w.Flush()
+
+	// Videos already sent by the replay may also be queued live
+	replayed := make(map[primitive.ObjectID]bool)
+	if lastEventID != nil {
+		if err := sh.replay(w, *lastEventID, filter, replayed); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(sh.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+
+		case video, ok := <-subscription.Events:
+			if !ok {
+				// Dropped for falling behind, or shutting down: the client
+				// reconnects with Last-Event-ID and catches up by replay
+				return
+			}
+			if replayed[video.ID] {
+				continue
+			}
+			if err := writeVideoEvent(w, video); err != nil {
+				return
+			}
+			w.Flush()
+
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
+
+// replay sends the videos stored after lastEventID, oldest first, or a
+// reset event when there are too many
+func (sh *StreamHandler) replay(w gin.ResponseWriter, lastEventID primitive.ObjectID, filter services.VideoEventFilter, replayed map[primitive.ObjectID]bool) error {
+	videoFilter := repository.VideoFilter{
+		SearchQueries: filter.Queries,
+		ChannelIDs:    filter.ChannelIDs,
+	}
+
+	after := lastEventID
+	for len(replayed) < maxStreamReplay {
+		videos, err := sh.videoRepo.GetStoredAfter(after, videoFilter, streamReplayPageSize)
+		if err != nil {
+			log.Printf("❌ Error replaying live feed after %s: %v", lastEventID.Hex(), err)
+			return writeResetEvent(w)
+		}
+
+		for i := range videos {
+			if err := writeVideoEvent(w, &videos[i]); err != nil {
+				return err
+			}
+			replayed[videos[i].ID] = true
+			after = videos[i].ID
+		}
+		w.Flush()
+
+		if len(videos) < streamReplayPageSize {
+			return nil
+		}
+	}
+
+	return writeResetEvent(w)
+}
+
+func writeVideoEvent(w io.Writer, video *models.Video) error {
+	data, err := json.Marshal(video)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: video\ndata: %s\n\n", video.ID.Hex(), data)
+	return err
+}
+
+// writeResetEvent tells the client it missed videos that won't be replayed
+// and should refetch the list. Its id moves the client's resume point to
+// now, so the next reconnect doesn't replay the same backlog.
+func writeResetEvent(w gin.ResponseWriter) error {
+	resumeID := primitive.NewObjectIDFromTimestamp(time.Now())
+	if _, err := fmt.Fprintf(w, "id: %s\nevent: reset\ndata: {}\n\n", resumeID.Hex()); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/services"
+)
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 25
+)
+
+type SuggestHandler struct {
+	suggestService *services.SuggestService
+}
+
+func NewSuggestHandler(suggestService *services.SuggestService) *SuggestHandler {
+	return &SuggestHandler{
+		suggestService: suggestService,
+	}
+}
+
+// Suggest - Completions for a partially typed query from title terms,
+// channel names and configured search queries
+func (sh *SuggestHandler) Suggest(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Query prefix cannot be empty",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSuggestLimit)))
+	if err != nil || limit < 1 || limit > maxSuggestLimit {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "limit must be between 1 and " + strconv.Itoa(maxSuggestLimit),
+		})
+		return
+	}
+
+	suggestions, err := sh.suggestService.Suggest(c.Request.Context(), prefix, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch suggestions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":       prefix,
+		"suggestions": suggestions,
+	})
+}
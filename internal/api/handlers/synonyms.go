@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+type SynonymHandler struct {
+	synonymRepo    *repository.SynonymRepository
+	synonymService *services.SynonymService
+}
+
+func NewSynonymHandler(synonymRepo *repository.SynonymRepository, synonymService *services.SynonymService) *SynonymHandler {
+	return &SynonymHandler{
+		synonymRepo:    synonymRepo,
+		synonymService: synonymService,
+	}
+}
+
+type synonymGroupRequest struct {
+	Name    string   `json:"name"`
+	Terms   []string `json:"terms"`
+	Enabled *bool    `json:"enabled"`
+}
+
+func (req synonymGroupRequest) toGroup() *models.SynonymGroup {
+	group := &models.SynonymGroup{
+		Name:    strings.TrimSpace(req.Name),
+		Enabled: true,
+	}
+	for _, term := range req.Terms {
+		group.Terms = append(group.Terms, strings.TrimSpace(term))
+	}
+	if req.Enabled != nil {
+		group.Enabled = *req.Enabled
+	}
+	return group
+}
+
+// ListSynonyms - All synonym and transliteration groups
+func (sh *SynonymHandler) ListSynonyms(c *gin.Context) {
+	groups, err := sh.synonymRepo.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch synonym groups",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"synonyms": groups})
+}
+
+func (sh *SynonymHandler) GetSynonym(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	group, err := sh.synonymRepo.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+	if group == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Synonym group not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (sh *SynonymHandler) CreateSynonym(c *gin.Context) {
+	var req synonymGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	group := req.toGroup()
+	if err := services.ValidateSynonymGroup(group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := sh.synonymRepo.Create(group); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+	sh.applyChanges()
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (sh *SynonymHandler) UpdateSynonym(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req synonymGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	group := req.toGroup()
+	group.ID = id
+	if err := services.ValidateSynonymGroup(group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	found, err := sh.synonymRepo.Update(group)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Synonym group not found"})
+		return
+	}
+	sh.applyChanges()
+
+	updated, err := sh.synonymRepo.GetByID(id)
+	if err != nil || updated == nil {
+		c.JSON(http.StatusOK, group)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (sh *SynonymHandler) DeleteSynonym(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	found, err := sh.synonymRepo.Delete(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to delete synonym group",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Synonym group not found"})
+		return
+	}
+	sh.applyChanges()
+
+	c.Status(http.StatusNoContent)
+}
+
+// applyChanges rewrites the synonym markers of stored videos in the
+// background; queries use the edited dictionary once that is done
+func (sh *SynonymHandler) applyChanges() {
+	sh.synonymService.ReindexVideos()
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+)
+
+type TrendingHandler struct {
+	trendingRepo  *repository.TrendingRepository
+	defaultRegion string
+}
+
+func NewTrendingHandler(trendingRepo *repository.TrendingRepository, defaultRegion string) *TrendingHandler {
+	return &TrendingHandler{
+		trendingRepo:  trendingRepo,
+		defaultRegion: defaultRegion,
+	}
+}
+
+// GetTrending - Latest trending chart for a region and category, with rank movement
+func (th *TrendingHandler) GetTrending(c *gin.Context) {
+	region := strings.ToUpper(c.DefaultQuery("region", th.defaultRegion))
+	categoryID := c.DefaultQuery("category", models.TrendingAllCategories)
+
+	snapshot, err := th.trendingRepo.GetLatest(region, categoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch trending chart",
+			"details": err.Error(),
+		})
+		return
+	}
+	if snapshot == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No trending chart for this region and category (check TRENDING_REGIONS and TRENDING_CATEGORIES)",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
@@ -1,13 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"fampay-youtube-api/internal/repository"
-	"fampay-youtube-api/internal/utils"
 )
 
 type VideoHandler struct {
@@ -22,31 +21,38 @@ func NewVideoHandler(videoRepo *repository.VideoRepository) *VideoHandler {
 
 // Enhanced GetVideos with sorting support
 func (vh *VideoHandler) GetVideos(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "12"))
-	sortBy := c.DefaultQuery("sort", "latest") // latest, oldest, title, channel
+	sortBy := c.DefaultQuery("sort", "latest") // latest, oldest, title, channel, shortest, longest
 
-	// Validate parameters
-	if page < 1 {
-		page = 1
-	}
-	if pageSize > 50 {
-		pageSize = 50
-	}
-	if pageSize < 1 {
-		pageSize = 12
+	// Page number or ?cursor= from an earlier response
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
 	}
 
 	// Validate sort parameter
 	validSorts := map[string]bool{
 		"latest": true, "oldest": true, "title": true, "channel": true,
+		"shortest": true, "longest": true,
 	}
 	if !validSorts[sortBy] {
 		sortBy = "latest"
 	}
 
+	// Optional filters (safety level, format, category)
+	videoFilter, err := parseVideoFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	// Get videos from repository with sorting
-	videos, total, err := vh.videoRepo.GetPaginated(page, pageSize, sortBy)
+	videos, pageInfo, err := vh.videoRepo.GetPaginated(pagination, sortBy, videoFilter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch videos",
@@ -56,6 +62,6 @@ func (vh *VideoHandler) GetVideos(c *gin.Context) {
 	}
 
 	// Create paginated response
-	response := utils.NewPaginatedResponse(videos, total, page, pageSize)
+	response := newPageResponse(c, videos, pagination, pageInfo, "/api/videos")
 	c.JSON(http.StatusOK, response)
 }
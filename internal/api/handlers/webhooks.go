@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+	"fampay-youtube-api/internal/worker"
+)
+
+type WebhookHandler struct {
+	webhookRepo *repository.WebhookRepository
+	dispatcher  *worker.WebhookDispatcher
+}
+
+func NewWebhookHandler(webhookRepo *repository.WebhookRepository, dispatcher *worker.WebhookDispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		webhookRepo: webhookRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+type webhookRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"` // Generated on create when empty; kept on update when empty
+	Queries    []string `json:"queries"`
+	ChannelIDs []string `json:"channel_ids"`
+	Keywords   []string `json:"keywords"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+func (req webhookRequest) toWebhook() *models.Webhook {
+	webhook := &models.Webhook{
+		Name:       strings.TrimSpace(req.Name),
+		URL:        strings.TrimSpace(req.URL),
+		Secret:     req.Secret,
+		Queries:    trimValues(req.Queries),
+		ChannelIDs: trimValues(req.ChannelIDs),
+		Keywords:   trimValues(req.Keywords),
+		Enabled:    true,
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+	return webhook
+}
+
+// webhookCreatedResponse is the only response that includes the signing
+// secret, so a generated one can be copied to the receiver
+type webhookCreatedResponse struct {
+	*models.Webhook
+	Secret string `json:"secret"`
+}
+
+func trimValues(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed = append(trimmed, strings.TrimSpace(value))
+	}
+	return trimmed
+}
+
+// ListWebhooks - All webhook subscriptions
+func (wh *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := wh.webhookRepo.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch webhooks",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (wh *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, ok := wh.loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (wh *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req webhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhook := req.toWebhook()
+	if err := services.ValidateWebhook(webhook); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if webhook.Secret == "" {
+		secret, err := services.GenerateWebhookSecret()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to create webhook",
+				"details": err.Error(),
+			})
+			return
+		}
+		webhook.Secret = secret
+	}
+
+	if err := wh.webhookRepo.Create(webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+	wh.applyChanges()
+
+	c.JSON(http.StatusCreated, webhookCreatedResponse{Webhook: webhook, Secret: webhook.Secret})
+}
+
+func (wh *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req webhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	webhook := req.toWebhook()
+	webhook.ID = id
+	if err := services.ValidateWebhook(webhook); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	found, err := wh.webhookRepo.Update(webhook)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return
+	}
+	wh.applyChanges()
+
+	updated, err := wh.webhookRepo.GetByID(id)
+	if err != nil || updated == nil {
+		c.JSON(http.StatusOK, webhook)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (wh *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	found, err := wh.webhookRepo.Delete(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to delete webhook",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return
+	}
+	wh.applyChanges()
+
+	c.Status(http.StatusNoContent)
+}
+
+// PingWebhook - Queues a signed ping event to check the receiver
+func (wh *WebhookHandler) PingWebhook(c *gin.Context) {
+	webhook, ok := wh.loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := wh.dispatcher.SendPing(webhook)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to queue ping",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// ListDeliveries - Delivery log of a webhook, newest first (?status=pending|delivered|failed)
+func (wh *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.DeliveryStatusPending, models.DeliveryStatusDelivered, models.DeliveryStatusFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, delivered or failed"})
+		return
+	}
+
+	pagination, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+
+	deliveries, pageInfo, err := wh.webhookRepo.GetDeliveries(id, status, pagination)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		respondInvalidCursor(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch webhook deliveries",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	response := newPageResponse(c, deliveries, pagination, pageInfo, "/api/admin/webhooks/"+id.Hex()+"/deliveries")
+	c.JSON(http.StatusOK, response)
+}
+
+// loadWebhook resolves the :id path parameter, responding 400 or 404
+func (wh *WebhookHandler) loadWebhook(c *gin.Context) (*models.Webhook, bool) {
+	id, ok := parseObjectID(c, "id")
+	if !ok {
+		return nil, false
+	}
+
+	webhook, err := wh.webhookRepo.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch webhook",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+	if webhook == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// applyChanges makes new deliveries use the edited subscriptions right away
+func (wh *WebhookHandler) applyChanges() {
+	if err := wh.dispatcher.Reload(); err != nil {
+		log.Printf("⚠️ Failed to reload webhooks: %v", err)
+	}
+}
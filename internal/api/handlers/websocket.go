@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/services"
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = 25 * time.Second // Must be shorter than wsPongTimeout
+
+	// Replies queued for the writer; a client that doesn't read them is too slow
+	wsOutboxSize = 16
+)
+
+// WebSocket protocol message types
+const (
+	wsTypeSubscribe    = "subscribe"
+	wsTypeUnsubscribe  = "unsubscribe"
+	wsTypePing         = "ping"
+	wsTypeWelcome      = "welcome"
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypePong         = "pong"
+	wsTypeVideo        = "video"
+	wsTypeError        = "error"
+)
+
+// wsClientMessage is a message sent by the client
+type wsClientMessage struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id,omitempty"` // Echoed in the reply
+	Queries    []string `json:"queries,omitempty"`
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+}
+
+// wsServerMessage is a message sent to the client
+type wsServerMessage struct {
+	Type          string           `json:"type"`
+	ID            string           `json:"id,omitempty"`
+	Subscriptions *wsSubscriptions `json:"subscriptions,omitempty"`
+	Limits        *wsLimits        `json:"limits,omitempty"`
+	Video         *models.Video    `json:"video,omitempty"`
+	Error         string           `json:"error,omitempty"`
+}
+
+type wsSubscriptions struct {
+	Queries    []string `json:"queries"`
+	ChannelIDs []string `json:"channel_ids"`
+}
+
+type wsLimits struct {
+	MaxSubscriptions     int `json:"max_subscriptions"`
+	MaxMessageSize       int `json:"max_message_size"`
+	MaxMessagesPerMinute int `json:"max_messages_per_minute"`
+}
+
+type WebSocketHandler struct {
+	broadcaster *services.VideoBroadcaster
+	limits      wsLimits
+	upgrader    websocket.Upgrader
+}
+
+func NewWebSocketHandler(broadcaster *services.VideoBroadcaster, cfg config.StreamConfig) *WebSocketHandler {
+	return &WebSocketHandler{
+		broadcaster: broadcaster,
+		limits: wsLimits{
+			MaxSubscriptions:     cfg.MaxSubscriptions,
+			MaxMessageSize:       cfg.MaxMessageSize,
+			MaxMessagesPerMinute: cfg.MaxMessagesPerMinute,
+		},
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			// The feed is public and cookie-free, like the CORS policy
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// LiveFeed - WebSocket feed of newly stored videos. Clients send
+// {"type":"subscribe","queries":[...],"channel_ids":[...]} and receive a
+// "video" message for every new video from a followed query or channel;
+// "unsubscribe" (without lists: everything) and "ping" are also accepted.
+func (wh *WebSocketHandler) LiveFeed(c *gin.Context) {
+	// Nothing is delivered until the client subscribes
+	subscription, err := wh.broadcaster.Subscribe(services.VideoEventFilter{MatchAny: true})
+	if errors.Is(err, services.ErrTooManySubscribers) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many live feed clients, retry later"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to open live feed",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer subscription.Close()
+
+	conn, err := wh.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // The upgrader has already responded
+	}
+	defer conn.Close()
+
+	session := &wsSession{
+		conn:         conn,
+		subscription: subscription,
+		limits:       wh.limits,
+		outbox:       make(chan wsServerMessage, wsOutboxSize),
+		done:         make(chan struct{}),
+		queries:      make(map[string]bool),
+		channelIDs:   make(map[string]bool),
+	}
+	go session.writeLoop()
+	defer close(session.done)
+
+	session.send(wsServerMessage{Type: wsTypeWelcome, Limits: &session.limits})
+	session.readLoop()
+}
+
+// wsSession is one WebSocket connection. The handler goroutine reads
+// client messages; writeLoop is the only writer of data frames.
+type wsSession struct {
+	conn         *websocket.Conn
+	subscription *services.VideoSubscription
+	limits       wsLimits
+	outbox       chan wsServerMessage
+	done         chan struct{} // Closed when the reader stops
+
+	// Owned by the reader
+	queries    map[string]bool
+	channelIDs map[string]bool
+}
+
+func (s *wsSession) readLoop() {
+	s.conn.SetReadLimit(int64(s.limits.MaxMessageSize))
+	s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	windowStart, count := time.Now(), 0
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return // Closed, timed out or over the size limit
+		}
+		s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+
+		if time.Since(windowStart) >= time.Minute {
+			windowStart, count = time.Now(), 0
+		}
+		count++
+		if count > s.limits.MaxMessagesPerMinute {
+			s.close(websocket.ClosePolicyViolation, "too many messages")
+			return
+		}
+
+		var msg wsClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			if !s.send(wsServerMessage{Type: wsTypeError, Error: "invalid JSON message"}) {
+				return
+			}
+			continue
+		}
+
+		var reply wsServerMessage
+		switch msg.Type {
+		case wsTypeSubscribe:
+			reply = s.subscribe(msg)
+		case wsTypeUnsubscribe:
+			reply = s.unsubscribe(msg)
+		case wsTypePing:
+			reply = wsServerMessage{Type: wsTypePong}
+		default:
+			reply = wsServerMessage{Type: wsTypeError, Error: "unknown message type (expected subscribe, unsubscribe or ping)"}
+		}
+		reply.ID = msg.ID
+		if !s.send(reply) {
+			return
+		}
+	}
+}
+
+func (s *wsSession) subscribe(msg wsClientMessage) wsServerMessage {
+	queries, channelIDs, err := cleanTopics(msg)
+	if err != nil {
+		return wsServerMessage{Type: wsTypeError, Error: err.Error()}
+	}
+	if len(queries)+len(channelIDs) == 0 {
+		return wsServerMessage{Type: wsTypeError, Error: "subscribe needs queries or channel_ids"}
+	}
+
+	added := 0
+	for _, query := range queries {
+		if !s.queries[query] {
+			added++
+		}
+	}
+	for _, channelID := range channelIDs {
+		if !s.channelIDs[channelID] {
+			added++
+		}
+	}
+	if len(s.queries)+len(s.channelIDs)+added > s.limits.MaxSubscriptions {
+		return wsServerMessage{Type: wsTypeError, Error: fmt.Sprintf("too many subscriptions (max %d)", s.limits.MaxSubscriptions)}
+	}
+
+	for _, query := range queries {
+		s.queries[query] = true
+	}
+	for _, channelID := range channelIDs {
+		s.channelIDs[channelID] = true
+	}
+	return s.applyTopics(wsTypeSubscribed)
+}
+
+func (s *wsSession) unsubscribe(msg wsClientMessage) wsServerMessage {
+	queries, channelIDs, err := cleanTopics(msg)
+	if err != nil {
+		return wsServerMessage{Type: wsTypeError, Error: err.Error()}
+	}
+
+	if len(queries)+len(channelIDs) == 0 {
+		s.queries = make(map[string]bool)
+		s.channelIDs = make(map[string]bool)
+	}
+	for _, query := range queries {
+		delete(s.queries, query)
+	}
+	for _, channelID := range channelIDs {
+		delete(s.channelIDs, channelID)
+	}
+	return s.applyTopics(wsTypeUnsubscribed)
+}
+
+// applyTopics points the feed subscription at the followed topics and
+// builds the reply listing them
+func (s *wsSession) applyTopics(replyType string) wsServerMessage {
+	topics := &wsSubscriptions{
+		Queries:    sortedKeys(s.queries),
+		ChannelIDs: sortedKeys(s.channelIDs),
+	}
+	s.subscription.SetFilter(services.VideoEventFilter{
+		Queries:    topics.Queries,
+		ChannelIDs: topics.ChannelIDs,
+		MatchAny:   true,
+	})
+	return wsServerMessage{Type: replyType, Subscriptions: topics}
+}
+
+// send queues a reply for the writer. A client whose replies pile up is
+// not reading and is disconnected.
+func (s *wsSession) send(msg wsServerMessage) bool {
+	select {
+	case s.outbox <- msg:
+		return true
+	default:
+		s.close(websocket.CloseTryAgainLater, "client too slow")
+		return false
+	}
+}
+
+func (s *wsSession) writeLoop() {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	defer s.conn.Close() // Unblocks the reader after a write failure
+
+	for {
+		select {
+		case msg := <-s.outbox:
+			if err := s.write(msg); err != nil {
+				return
+			}
+
+		case video, ok := <-s.subscription.Events:
+			if !ok {
+				if s.subscription.Overflowed() {
+					s.close(websocket.CloseTryAgainLater, "client too slow")
+				} else {
+					s.close(websocket.CloseGoingAway, "server shutting down")
+				}
+				return
+			}
+			if err := s.write(wsServerMessage{Type: wsTypeVideo, Video: video}); err != nil {
+				return
+			}
+
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// write sends one message; a client that can't take it within the write
+// timeout is disconnected
+func (s *wsSession) write(msg wsServerMessage) error {
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return s.conn.WriteJSON(msg)
+}
+
+// close sends a close frame; it is safe to call from either goroutine
+func (s *wsSession) close(code int, reason string) {
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteTimeout))
+}
+
+// cleanTopics trims a message's queries and channel IDs, rejecting empty values
+func cleanTopics(msg wsClientMessage) ([]string, []string, error) {
+	clean := func(values []string, name string) ([]string, error) {
+		cleaned := make([]string, 0, len(values))
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return nil, errors.New(name + " cannot contain empty values")
+			}
+			cleaned = append(cleaned, value)
+		}
+		return cleaned, nil
+	}
+
+	queries, err := clean(msg.Queries, "queries")
+	if err != nil {
+		return nil, nil, err
+	}
+	channelIDs, err := clean(msg.ChannelIDs, "channel_ids")
+	if err != nil {
+		return nil, nil, err
+	}
+	return queries, channelIDs, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/services"
+)
+
+// dialLiveFeed serves the WebSocket feed and connects a client, reading
+// the welcome message
+func dialLiveFeed(t *testing.T, broadcaster *services.VideoBroadcaster, cfg config.StreamConfig) *websocket.Conn {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", NewWebSocketHandler(broadcaster, cfg).LiveFeed)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if welcome := readServerMessage(t, conn); welcome.Type != wsTypeWelcome {
+		t.Fatalf("first message = %+v, want welcome", welcome)
+	}
+	return conn
+}
+
+func readServerMessage(t *testing.T, conn *websocket.Conn) wsServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg wsServerMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	return msg
+}
+
+func TestLiveFeedSubscriptions(t *testing.T) {
+	broadcaster := services.NewVideoBroadcaster(nil, 10, 4)
+	conn := dialLiveFeed(t, broadcaster, config.StreamConfig{MaxSubscriptions: 3, MaxMessageSize: 4096, MaxMessagesPerMinute: 100})
+
+	steps := []struct {
+		send      wsClientMessage
+		wantType  string
+		wantError string
+		wantTopic []string // Queries then channel IDs followed afterwards
+	}{
+		{send: wsClientMessage{Type: "subscribe", Queries: []string{"cricket", "football"}, ChannelIDs: []string{"UCa"}}, wantType: wsTypeSubscribed, wantTopic: []string{"cricket", "football", "UCa"}},
+		{send: wsClientMessage{Type: "subscribe", Queries: []string{"tennis"}}, wantType: wsTypeError, wantError: "too many subscriptions (max 3)"},
+		// Topics already followed don't count again
+		{send: wsClientMessage{Type: "subscribe", Queries: []string{"cricket"}}, wantType: wsTypeSubscribed, wantTopic: []string{"cricket", "football", "UCa"}},
+		{send: wsClientMessage{Type: "unsubscribe", Queries: []string{"football"}}, wantType: wsTypeUnsubscribed, wantTopic: []string{"cricket", "UCa"}},
+		{send: wsClientMessage{Type: "subscribe", Queries: []string{"tennis"}}, wantType: wsTypeSubscribed, wantTopic: []string{"cricket", "tennis", "UCa"}},
+		{send: wsClientMessage{Type: "subscribe", Queries: []string{" "}}, wantType: wsTypeError, wantError: "queries cannot contain empty values"},
+		{send: wsClientMessage{Type: "subscribe"}, wantType: wsTypeError, wantError: "subscribe needs queries or channel_ids"},
+		{send: wsClientMessage{Type: "shout"}, wantType: wsTypeError, wantError: "unknown message type"},
+	}
+
+	for i, step := range steps {
+		step.send.ID = string(rune('a' + i))
+		if err := conn.WriteJSON(step.send); err != nil {
+			t.Fatal(err)
+		}
+
+		reply := readServerMessage(t, conn)
+		if reply.Type != step.wantType || reply.ID != step.send.ID || !strings.Contains(reply.Error, step.wantError) {
+			t.Fatalf("step %d: reply = %+v, want %s %q", i, reply, step.wantType, step.wantError)
+		}
+		if step.wantTopic != nil {
+			topics := append(append([]string{}, reply.Subscriptions.Queries...), reply.Subscriptions.ChannelIDs...)
+			if strings.Join(topics, ",") != strings.Join(step.wantTopic, ",") {
+				t.Errorf("step %d: subscriptions = %v, want %v", i, topics, step.wantTopic)
+			}
+		}
+	}
+
+}
+
+func TestLiveFeedRateLimit(t *testing.T) {
+	broadcaster := services.NewVideoBroadcaster(nil, 10, 4)
+	conn := dialLiveFeed(t, broadcaster, config.StreamConfig{MaxSubscriptions: 3, MaxMessageSize: 4096, MaxMessagesPerMinute: 3})
+
+	for i := 0; i < 4; i++ {
+		if err := conn.WriteJSON(wsClientMessage{Type: "ping"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Replies still queued when the connection closes may be lost
+	var pongs int
+	for {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var reply wsServerMessage
+		err := conn.ReadJSON(&reply)
+		if err == nil {
+			pongs++
+			continue
+		}
+
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != websocket.ClosePolicyViolation {
+			t.Errorf("after the limit got %v, want a policy violation close", err)
+		}
+		break
+	}
+	if pongs > 3 {
+		t.Errorf("got %d pongs, want at most 3", pongs)
+	}
+}
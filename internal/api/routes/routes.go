@@ -4,16 +4,41 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
 
 	"fampay-youtube-api/internal/api/handlers"
 	"fampay-youtube-api/internal/api/middleware"
 	"fampay-youtube-api/internal/config"
 	"fampay-youtube-api/internal/repository"
 	"fampay-youtube-api/internal/services"
+	"fampay-youtube-api/internal/worker"
 )
 
-func SetupRouter(videoRepo *repository.VideoRepository, redisClient *redis.Client, cfg *config.Config) *gin.Engine {
+// Deps are the repositories, services and workers the API handlers use
+type Deps struct {
+	Config *config.Config
+
+	VideoRepo       *repository.VideoRepository
+	RuleRepo        *repository.FilterRuleRepository
+	CommentRepo     *repository.CommentRepository
+	ChannelRepo     *repository.ChannelRepository
+	TrendingRepo    *repository.TrendingRepository
+	CategoryRepo    *repository.CategoryRepository
+	SynonymRepo     *repository.SynonymRepository
+	SavedSearchRepo *repository.SavedSearchRepository
+	WebhookRepo     *repository.WebhookRepository
+
+	SuggestService   *services.SuggestService
+	SpellingService  *services.SpellingService
+	SynonymService   *services.SynonymService
+	VideoBroadcaster *services.VideoBroadcaster
+	YouTubeService   *services.YouTubeService // Shared with the workers so key rotation applies everywhere
+
+	VideoFetcher      *worker.VideoFetcher
+	WebhookDispatcher *worker.WebhookDispatcher
+}
+
+func SetupRouter(deps Deps) *gin.Engine {
+	cfg := deps.Config
 	router := gin.New()
 
 	// Middleware
@@ -21,19 +46,26 @@ func SetupRouter(videoRepo *repository.VideoRepository, redisClient *redis.Clien
 	router.Use(middleware.CORS())
 	router.Use(gin.Recovery())
 
-	// Initialize YouTube service for live search (bonus feature)
-	youtubeService := services.NewYouTubeService(
-		cfg.YouTube.APIKeys,
-		cfg.YouTube.SearchQueries,
-		cfg.YouTube.MaxResultsPerQuery,
-		cfg.YouTube.RegionCode,
-		cfg.YouTube.RelevanceLanguage,
-	)
+	// YouTube service for live search (bonus feature)
+	youtubeService := deps.YouTubeService
 
 	// Initialize handlers
-	videoHandler := handlers.NewVideoHandler(videoRepo)
-	searchHandler := handlers.NewSearchHandler(videoRepo)
+	videoHandler := handlers.NewVideoHandler(deps.VideoRepo)
+	searchHandler := handlers.NewSearchHandler(deps.VideoRepo, deps.SpellingService, deps.SynonymService)
 	youtubeSearchHandler := handlers.NewYouTubeSearchHandler(youtubeService)
+	scheduleHandler := handlers.NewScheduleHandler(deps.VideoFetcher)
+	filterRuleHandler := handlers.NewFilterRuleHandler(deps.RuleRepo)
+	liveHandler := handlers.NewLiveHandler(deps.VideoRepo, cfg.YouTube.SearchQueries)
+	commentHandler := handlers.NewCommentHandler(deps.CommentRepo, deps.VideoRepo)
+	channelHandler := handlers.NewChannelHandler(deps.ChannelRepo, deps.VideoRepo)
+	trendingHandler := handlers.NewTrendingHandler(deps.TrendingRepo, cfg.YouTube.RegionCode)
+	categoryHandler := handlers.NewCategoryHandler(deps.CategoryRepo, cfg.YouTube.RegionCode)
+	suggestHandler := handlers.NewSuggestHandler(deps.SuggestService)
+	synonymHandler := handlers.NewSynonymHandler(deps.SynonymRepo, deps.SynonymService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(deps.SavedSearchRepo, deps.VideoRepo)
+	webhookHandler := handlers.NewWebhookHandler(deps.WebhookRepo, deps.WebhookDispatcher)
+	streamHandler := handlers.NewStreamHandler(deps.VideoRepo, deps.VideoBroadcaster, time.Duration(cfg.Stream.HeartbeatInterval)*time.Second)
+	webSocketHandler := handlers.NewWebSocketHandler(deps.VideoBroadcaster, cfg.Stream)
 
 	// FamPay Required API endpoints
 	api := router.Group("/api")
@@ -46,8 +78,78 @@ func SetupRouter(videoRepo *repository.VideoRepository, redisClient *redis.Clien
 			// FamPay Requirement: Basic search API for title and description
 			videos.GET("/search", searchHandler.SearchVideos)
 
+			// Server-Sent Events feed of newly stored videos
+			videos.GET("/stream", streamHandler.StreamVideos)
+
+			// WebSocket feed with subscribe/unsubscribe per query or channel
+			videos.GET("/ws", webSocketHandler.LiveFeed)
+
+			// Autocomplete for the search box
+			videos.GET("/suggest", suggestHandler.Suggest)
+
 			// Bonus: Live YouTube search
 			videos.GET("/youtube-search", youtubeSearchHandler.LiveSearch)
+
+			// Top comments of a stored video
+			videos.GET("/:video_id/comments", commentHandler.GetComments)
+		}
+
+		channels := api.Group("/channels")
+		{
+			channels.GET("", channelHandler.GetChannels)
+			channels.GET("/:channel_id", channelHandler.GetChannel)
+			channels.GET("/:channel_id/videos", channelHandler.GetChannelVideos)
+		}
+
+		// Saved searches and the videos matched since the last check
+		savedSearches := api.Group("/saved-searches")
+		{
+			savedSearches.GET("", savedSearchHandler.ListSavedSearches)
+			savedSearches.POST("", savedSearchHandler.CreateSavedSearch)
+			savedSearches.GET("/:id", savedSearchHandler.GetSavedSearch)
+			savedSearches.PUT("/:id", savedSearchHandler.UpdateSavedSearch)
+			savedSearches.DELETE("/:id", savedSearchHandler.DeleteSavedSearch)
+			savedSearches.GET("/:id/new", savedSearchHandler.GetNewMatches)
+		}
+
+		// Per-query fetch schedules and next run times
+		api.GET("/schedules", scheduleHandler.GetSchedules)
+
+		// YouTube mostPopular charts per region and category
+		api.GET("/trending", trendingHandler.GetTrending)
+
+		// Video category catalog per region
+		api.GET("/categories", categoryHandler.GetCategories)
+
+		// Live now and upcoming broadcasts per search query
+		api.GET("/live", liveHandler.GetLive)
+
+		// Admin API (requires X-Admin-Key)
+		admin := api.Group("/admin", middleware.AdminAuth(cfg.Admin.APIKey))
+		{
+			// Ingestion filter rules
+			admin.GET("/rules", filterRuleHandler.ListRules)
+			admin.POST("/rules", filterRuleHandler.CreateRule)
+			admin.GET("/rules/:id", filterRuleHandler.GetRule)
+			admin.PUT("/rules/:id", filterRuleHandler.UpdateRule)
+			admin.DELETE("/rules/:id", filterRuleHandler.DeleteRule)
+			admin.GET("/rejections", filterRuleHandler.ListRejections)
+
+			// Search synonym and transliteration dictionary
+			admin.GET("/synonyms", synonymHandler.ListSynonyms)
+			admin.POST("/synonyms", synonymHandler.CreateSynonym)
+			admin.GET("/synonyms/:id", synonymHandler.GetSynonym)
+			admin.PUT("/synonyms/:id", synonymHandler.UpdateSynonym)
+			admin.DELETE("/synonyms/:id", synonymHandler.DeleteSynonym)
+
+			// Webhooks on newly ingested videos and their delivery log
+			admin.GET("/webhooks", webhookHandler.ListWebhooks)
+			admin.POST("/webhooks", webhookHandler.CreateWebhook)
+			admin.GET("/webhooks/:id", webhookHandler.GetWebhook)
+			admin.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+			admin.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+			admin.POST("/webhooks/:id/ping", webhookHandler.PingWebhook)
+			admin.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
 		}
 	}
 
@@ -61,7 +163,7 @@ func SetupRouter(videoRepo *repository.VideoRepository, redisClient *redis.Clien
 			"features":   []string{"stored_videos", "search_api", "background_fetcher", "multiple_api_keys"},
 			"api_status": apiStatus,
 			"compliance": gin.H{
-				"background_fetcher": "✅ Running every 10 seconds",
+				"background_fetcher": "✅ Running on per-query schedules",
 				"pagination":         "✅ Implemented",
 				"search_api":         "✅ Title and description search",
 				"sorting":            "✅ Published datetime DESC",
@@ -1,6 +1,7 @@
 package config
 
 import (
+    "fmt"
     "os"
     "strconv"
     "strings"
@@ -12,6 +13,10 @@ type Config struct {
     MongoDB  MongoDBConfig
     Redis    RedisConfig
     YouTube  YouTubeConfig
+    Admin    AdminConfig
+    Search   SearchConfig
+    Webhooks WebhookConfig
+    Stream   StreamConfig
 }
 
 type ServerConfig struct {
@@ -32,6 +37,36 @@ type RedisConfig struct {
     DB       int
 }
 
+type AdminConfig struct {
+    APIKey string // Admin endpoints are disabled when empty
+}
+
+type SearchConfig struct {
+    SuggestHalfLife          int // Hours for a suggestion's weight to halve
+    VocabularyReloadInterval int // Seconds between reloads of the spelling vocabulary
+    SavedSearchInterval      int // Seconds between saved search evaluations of new videos
+}
+
+type WebhookConfig struct {
+    DeliveryInterval int // Seconds between checks for due deliveries
+    Timeout          int // Seconds before a delivery attempt is abandoned
+    MaxAttempts      int // Attempts per delivery before it is marked failed
+    RetryBaseDelay   int // Seconds before the first retry; doubles on each further retry
+    RetryMaxDelay    int // Longest wait in seconds between retries
+    DisableAfter     int // Consecutive failed attempts before a webhook is disabled (0 never disables)
+}
+
+type StreamConfig struct {
+    MaxClients        int // Live feed connections per instance
+    BufferSize        int // Videos queued per connection before a slow client is dropped
+    HeartbeatInterval int // Seconds between keep-alive comments on idle streams
+
+    // WebSocket connection limits
+    MaxSubscriptions     int // Queries and channels one connection can follow
+    MaxMessageSize       int // Bytes of the largest client message
+    MaxMessagesPerMinute int // Client messages before a connection is closed
+}
+
 type YouTubeConfig struct {
     APIKeys            []string
     SearchQueries      []string
@@ -39,6 +74,35 @@ type YouTubeConfig struct {
     MaxResultsPerQuery int
     RegionCode         string
     RelevanceLanguage  string
+    SafeSearch         string            // none, moderate or strict
+    QuerySafeSearch    map[string]string // Per-query safe-search overrides
+
+    // Per-query schedules: "interval", "adaptive" or "cron:<expression>"
+    QuerySchedules      map[string]string
+    AdaptiveMinInterval int
+    AdaptiveMaxInterval int
+
+    BroadcastCheckInterval int // Seconds between live/upcoming broadcast state checks
+    ChannelRefreshInterval int // Seconds between channel refresh runs
+    ChannelStaleAfter      int // Hours before stored channel details are refreshed
+
+    // mostPopular chart snapshots ("0" is the overall chart)
+    TrendingRegions    []string
+    TrendingCategories []string
+    TrendingInterval   int // Seconds between chart snapshots
+    TrendingMaxResults int
+
+    // Video category catalog
+    CategoryRegions         []string
+    CategoryRefreshInterval int // Seconds between catalog refreshes
+
+    // Per-query cap of top-level comments fetched for newly stored videos (0 = disabled)
+    QueryCommentLimits map[string]int
+
+    // Caption/transcript ingestion for newly stored videos
+    CaptionsEnabled   bool
+    CaptionLanguages  []string // Preferred caption languages, in order
+    CaptionFixtureDir string   // Read captions from local files instead of YouTube
 }
 
 func Load() (*Config, error) {
@@ -84,12 +148,88 @@ func Load() (*Config, error) {
             MaxResultsPerQuery: getEnvInt("MAX_RESULTS_PER_QUERY", 50),
             RegionCode:         getEnv("REGION_CODE", "IN"),
             RelevanceLanguage:  getEnv("RELEVANCE_LANGUAGE", "en"),
+            SafeSearch:         getEnv("SAFE_SEARCH", "moderate"),
+            QuerySafeSearch:    getEnvQueryMap("QUERY_SAFE_SEARCH"),
+
+            QuerySchedules:      getEnvQueryMap("QUERY_SCHEDULES"),
+            AdaptiveMinInterval: getEnvInt("ADAPTIVE_MIN_INTERVAL", getEnvInt("FETCH_INTERVAL", 10)),
+            AdaptiveMaxInterval: getEnvInt("ADAPTIVE_MAX_INTERVAL", 600),
+
+            BroadcastCheckInterval: getEnvInt("BROADCAST_CHECK_INTERVAL", 60),
+            ChannelRefreshInterval: getEnvInt("CHANNEL_REFRESH_INTERVAL", 3600),
+            ChannelStaleAfter:      getEnvInt("CHANNEL_STALE_AFTER", 24),
+
+            TrendingRegions:    getEnvList("TRENDING_REGIONS", getEnv("REGION_CODE", "IN")),
+            TrendingCategories: getEnvList("TRENDING_CATEGORIES", "0"),
+            TrendingInterval:   getEnvInt("TRENDING_INTERVAL", 1800),
+            TrendingMaxResults: getEnvInt("TRENDING_MAX_RESULTS", 50),
+
+            CategoryRegions:         getEnvList("CATEGORY_REGIONS", getEnv("REGION_CODE", "IN")),
+            CategoryRefreshInterval: getEnvInt("CATEGORY_REFRESH_INTERVAL", 86400),
+
+            QueryCommentLimits: getEnvQueryIntMap("QUERY_COMMENTS"),
+
+            CaptionsEnabled:   getEnvBool("CAPTIONS_ENABLED", false),
+            CaptionLanguages:  getEnvList("CAPTION_LANGUAGES", "en,hi"),
+            CaptionFixtureDir: getEnv("CAPTION_FIXTURE_DIR", ""),
+        },
+        Admin: AdminConfig{
+            APIKey: getEnv("ADMIN_API_KEY", ""),
+        },
+        Search: SearchConfig{
+            SuggestHalfLife:          getEnvInt("SUGGEST_HALF_LIFE", 168),
+            VocabularyReloadInterval: getEnvInt("VOCABULARY_RELOAD_INTERVAL", 300),
+            SavedSearchInterval:      getEnvInt("SAVED_SEARCH_INTERVAL", 10),
+        },
+        Webhooks: WebhookConfig{
+            DeliveryInterval: getEnvInt("WEBHOOK_DELIVERY_INTERVAL", 2),
+            Timeout:          getEnvInt("WEBHOOK_TIMEOUT", 10),
+            MaxAttempts:      getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+            RetryBaseDelay:   getEnvInt("WEBHOOK_RETRY_BASE_DELAY", 30),
+            RetryMaxDelay:    getEnvInt("WEBHOOK_RETRY_MAX_DELAY", 3600),
+            DisableAfter:     getEnvInt("WEBHOOK_DISABLE_AFTER", 20),
+        },
+        Stream: StreamConfig{
+            MaxClients:        getEnvInt("STREAM_MAX_CLIENTS", 1000),
+            BufferSize:        getEnvInt("STREAM_BUFFER_SIZE", 256),
+            HeartbeatInterval: getEnvInt("STREAM_HEARTBEAT_INTERVAL", 15),
+
+            MaxSubscriptions:     getEnvInt("WS_MAX_SUBSCRIPTIONS", 50),
+            MaxMessageSize:       getEnvInt("WS_MAX_MESSAGE_SIZE", 4096),
+            MaxMessagesPerMinute: getEnvInt("WS_MAX_MESSAGES_PER_MINUTE", 120),
         },
     }
 
+    if err := config.validate(); err != nil {
+        return nil, err
+    }
+
     return config, nil
 }
 
+// validate rejects settings the background workers can't run with
+func (c *Config) validate() error {
+    // Worker tickers panic on a non-positive interval
+    intervals := []struct {
+        name  string
+        value int
+    }{
+        {"BROADCAST_CHECK_INTERVAL", c.YouTube.BroadcastCheckInterval},
+        {"CHANNEL_REFRESH_INTERVAL", c.YouTube.ChannelRefreshInterval},
+        {"TRENDING_INTERVAL", c.YouTube.TrendingInterval},
+        {"CATEGORY_REFRESH_INTERVAL", c.YouTube.CategoryRefreshInterval},
+        {"SAVED_SEARCH_INTERVAL", c.Search.SavedSearchInterval},
+        {"WEBHOOK_DELIVERY_INTERVAL", c.Webhooks.DeliveryInterval},
+    }
+    for _, interval := range intervals {
+        if interval.value <= 0 {
+            return fmt.Errorf("%s must be a positive number of seconds, got %d", interval.name, interval.value)
+        }
+    }
+
+    return nil
+}
+
 func getEnv(key, defaultValue string) string {
     if value := os.Getenv(key); value != "" {
         return value
@@ -105,3 +245,53 @@ func getEnvInt(key string, defaultValue int) int {
     }
     return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+    if value := os.Getenv(key); value != "" {
+        if boolValue, err := strconv.ParseBool(value); err == nil {
+            return boolValue
+        }
+    }
+    return defaultValue
+}
+
+// getEnvList parses a comma-separated list, dropping empty entries
+func getEnvList(key, defaultValue string) []string {
+    var values []string
+    for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+        if trimmed := strings.TrimSpace(value); trimmed != "" {
+            values = append(values, trimmed)
+        }
+    }
+    return values
+}
+
+// getEnvQueryMap parses per-query settings of the form
+// "cricket=adaptive;news=cron:*/15 * * * *". Entries are separated by
+// semicolons so values may contain commas and spaces.
+func getEnvQueryMap(key string) map[string]string {
+    result := make(map[string]string)
+    for _, entry := range strings.Split(os.Getenv(key), ";") {
+        parts := strings.SplitN(entry, "=", 2)
+        if len(parts) != 2 {
+            continue
+        }
+        query := strings.TrimSpace(parts[0])
+        if query == "" {
+            continue
+        }
+        result[query] = strings.TrimSpace(parts[1])
+    }
+    return result
+}
+
+// getEnvQueryIntMap parses per-query integer settings such as "cricket=20;football=10"
+func getEnvQueryIntMap(key string) map[string]int {
+    result := make(map[string]int)
+    for query, value := range getEnvQueryMap(key) {
+        if intValue, err := strconv.Atoi(value); err == nil && intValue > 0 {
+            result[query] = intValue
+        }
+    }
+    return result
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRejectsNonPositiveIntervals(t *testing.T) {
+	tests := []struct {
+		env   string
+		value string
+	}{
+		{"BROADCAST_CHECK_INTERVAL", "0"},
+		{"BROADCAST_CHECK_INTERVAL", "-60"},
+		{"CHANNEL_REFRESH_INTERVAL", "0"},
+		{"TRENDING_INTERVAL", "-1"},
+		{"CATEGORY_REFRESH_INTERVAL", "0"},
+		{"SAVED_SEARCH_INTERVAL", "0"},
+		{"WEBHOOK_DELIVERY_INTERVAL", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.env) {
+				t.Errorf("Load() error = %v, want one naming %s", err, tt.env)
+			}
+		})
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.YouTube.BroadcastCheckInterval != 60 {
+		t.Errorf("BroadcastCheckInterval = %d, want 60", cfg.YouTube.BroadcastCheckInterval)
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Broadcast states for live streams and premieres
+const (
+	BroadcastUpcoming  = "upcoming"
+	BroadcastLive      = "live"
+	BroadcastCompleted = "completed"
+)
+
+// Broadcast tracks a live stream or premiere from liveStreamingDetails
+type Broadcast struct {
+	State             string     `json:"state" bson:"state"`
+	ScheduledStart    *time.Time `json:"scheduled_start,omitempty" bson:"scheduled_start,omitempty"`
+	ScheduledEnd      *time.Time `json:"scheduled_end,omitempty" bson:"scheduled_end,omitempty"`
+	ActualStart       *time.Time `json:"actual_start,omitempty" bson:"actual_start,omitempty"`
+	ActualEnd         *time.Time `json:"actual_end,omitempty" bson:"actual_end,omitempty"`
+	ConcurrentViewers int64      `json:"concurrent_viewers,omitempty" bson:"concurrent_viewers,omitempty"`
+	CheckedAt         time.Time  `json:"checked_at" bson:"checked_at"`
+}
+
+// LiveBroadcastContent returns the YouTube liveBroadcastContent value for the state
+func (b *Broadcast) LiveBroadcastContent() string {
+	switch b.State {
+	case BroadcastUpcoming:
+		return "upcoming"
+	case BroadcastLive:
+		return "live"
+	}
+	return "none"
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// VideoCategory is an entry of YouTube's per-region video category catalog
+type VideoCategory struct {
+	CategoryID string    `json:"category_id" bson:"category_id"`
+	Region     string    `json:"region" bson:"region"`
+	Title      string    `json:"title" bson:"title"`
+	Assignable bool      `json:"assignable" bson:"assignable"` // Whether uploaders can pick this category
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Channel struct {
+	ID                    primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ChannelID             string             `json:"channel_id" bson:"channel_id"`
+	Title                 string             `json:"title" bson:"title"`
+	Description           string             `json:"description" bson:"description"`
+	CustomURL             string             `json:"custom_url,omitempty" bson:"custom_url,omitempty"`
+	Country               string             `json:"country,omitempty" bson:"country,omitempty"`
+	AvatarURL             string             `json:"avatar_url,omitempty" bson:"avatar_url,omitempty"`
+	SubscriberCount       int64              `json:"subscriber_count" bson:"subscriber_count"`
+	HiddenSubscriberCount bool               `json:"hidden_subscriber_count" bson:"hidden_subscriber_count"`
+	VideoCount            int64              `json:"video_count" bson:"video_count"` // Uploads on YouTube
+	ViewCount             int64              `json:"view_count" bson:"view_count"`
+	PublishedAt           time.Time          `json:"published_at" bson:"published_at"`
+	RefreshedAt           time.Time          `json:"refreshed_at" bson:"refreshed_at"` // Last Channels.List fetch
+	CreatedAt             time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Comment is a top-level comment thread on a stored video
+type Comment struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CommentID       string             `json:"comment_id" bson:"comment_id"` // YouTube comment thread ID
+	VideoID         string             `json:"video_id" bson:"video_id"`
+	AuthorName      string             `json:"author_name" bson:"author_name"`
+	AuthorChannelID string             `json:"author_channel_id,omitempty" bson:"author_channel_id,omitempty"`
+	AuthorAvatarURL string             `json:"author_avatar_url,omitempty" bson:"author_avatar_url,omitempty"`
+	Text            string             `json:"text" bson:"text"`
+	LikeCount       int64              `json:"like_count" bson:"like_count"`
+	ReplyCount      int64              `json:"reply_count" bson:"reply_count"`
+	Rank            int                `json:"rank" bson:"rank"` // Position in YouTube's relevance order
+	PublishedAt     time.Time          `json:"published_at" bson:"published_at"`
+	EditedAt        time.Time          `json:"edited_at" bson:"edited_at"`
+	FetchedAt       time.Time          `json:"fetched_at" bson:"fetched_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// Facets that can be requested from /api/videos/search with ?facets=
+const (
+	FacetChannel      = "channel"
+	FacetSearchQuery  = "search_query"
+	FacetPublishedDay = "published_day"
+	FacetCategory     = "category"
+)
+
+// ValidFacet reports whether name is a supported search facet
+func ValidFacet(name string) bool {
+	switch name {
+	case FacetChannel, FacetSearchQuery, FacetPublishedDay, FacetCategory:
+		return true
+	}
+	return false
+}
+
+// FacetBucket counts the search results sharing one value of a facet
+type FacetBucket struct {
+	Value string `json:"value" bson:"_id"`
+	Label string `json:"label,omitempty" bson:"label,omitempty"` // Channel title or category name
+	Count int64  `json:"count" bson:"count"`
+}
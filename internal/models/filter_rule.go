@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ingestion filter rule types
+const (
+	RuleTypeChannelAllow   = "channel_allow"
+	RuleTypeChannelBlock   = "channel_block"
+	RuleTypeIncludeKeyword = "include_keyword"
+	RuleTypeExcludeKeyword = "exclude_keyword"
+	RuleTypeIncludeRegex   = "include_regex"
+	RuleTypeExcludeRegex   = "exclude_regex"
+)
+
+// Video fields that keyword and regex rules can be evaluated against
+const (
+	RuleFieldTitle       = "title"
+	RuleFieldDescription = "description"
+)
+
+// FilterRule is an ingestion filter. Keyword rules match whole words,
+// ignoring case and accents; regex rules match case-insensitively.
+type FilterRule struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string             `json:"name" bson:"name"`
+	Type      string             `json:"type" bson:"type"`
+	Query     string             `json:"query,omitempty" bson:"query,omitempty"`   // Empty for global rules
+	Fields    []string           `json:"fields,omitempty" bson:"fields,omitempty"` // Defaults to title and description
+	Values    []string           `json:"values" bson:"values"`                     // Channel IDs, keywords or regex patterns
+	Enabled   bool               `json:"enabled" bson:"enabled"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// RejectedVideo records why the ingestion filter dropped a video
+type RejectedVideo struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VideoID      string             `json:"video_id" bson:"video_id"`
+	Title        string             `json:"title" bson:"title"`
+	ChannelID    string             `json:"channel_id" bson:"channel_id"`
+	ChannelTitle string             `json:"channel_title" bson:"channel_title"`
+	SearchQuery  string             `json:"search_query" bson:"search_query"`
+	RuleID       primitive.ObjectID `json:"rule_id" bson:"rule_id"`
+	RuleName     string             `json:"rule_name" bson:"rule_name"`
+	RuleType     string             `json:"rule_type" bson:"rule_type"`
+	Reason       string             `json:"reason" bson:"reason"`
+	RejectedAt   time.Time          `json:"rejected_at" bson:"rejected_at"`
+	LastSeenAt   time.Time          `json:"last_seen_at" bson:"last_seen_at"`
+}
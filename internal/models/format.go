@@ -0,0 +1,18 @@
+package models
+
+// Video formats derived during enrichment
+const (
+	FormatShort    = "short"
+	FormatStandard = "standard"
+	FormatLive     = "live"
+	FormatPremiere = "premiere"
+)
+
+// ValidFormat reports whether format is one of the known video formats
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatShort, FormatStandard, FormatLive, FormatPremiere:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package models
+
+// Highlight shows why a search result matched. Text outside the highlight
+// tags is HTML-escaped.
+type Highlight struct {
+	Title       string   `json:"title"`                 // Whole title with matches wrapped in tags
+	Description []string `json:"description,omitempty"` // Up to three fragments around matches
+	Snippet     string   `json:"snippet"`               // Description window covering the most query terms
+}
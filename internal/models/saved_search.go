@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SavedSearch is a search query with filters that is re-evaluated against
+// every newly stored video
+type SavedSearch struct {
+	ID      primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name    string             `json:"name" bson:"name"`
+	Query   string             `json:"query" bson:"query"`                         // Same syntax as /api/videos/search?q=
+	Filters map[string]string  `json:"filters,omitempty" bson:"filters,omitempty"` // Listing filter parameters, e.g. {"lang": "hi"}
+	Sort    string             `json:"sort" bson:"sort"`                           // Order of returned matches
+
+	LastCheckedAt time.Time `json:"last_checked_at" bson:"last_checked_at"` // Matches after this are new
+	CreatedAt     time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" bson:"updated_at"`
+
+	// Matches recorded since the last check (single-search responses only)
+	NewMatches *int64 `json:"new_matches,omitempty" bson:"-"`
+}
+
+// SavedSearchMatch records a stored video matching a saved search
+type SavedSearchMatch struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SavedSearchID primitive.ObjectID `json:"saved_search_id" bson:"saved_search_id"`
+	VideoID       primitive.ObjectID `json:"video_id" bson:"video_id"` // _id of the stored video
+	MatchedAt     time.Time          `json:"matched_at" bson:"matched_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Schedule modes for background fetching of a search query
+const (
+	ScheduleModeInterval = "interval"
+	ScheduleModeAdaptive = "adaptive"
+	ScheduleModeCron     = "cron"
+)
+
+type QuerySchedule struct {
+	Query                string     `json:"query"`
+	Mode                 string     `json:"mode"`
+	Expression           string     `json:"expression,omitempty"` // Cron expression (cron mode only)
+	IntervalSeconds      int64      `json:"interval_seconds,omitempty"`
+	NextRun              time.Time  `json:"next_run"`
+	LastRun              *time.Time `json:"last_run,omitempty"`
+	LastNewVideos        int        `json:"last_new_videos"`
+	ConsecutiveEmptyRuns int        `json:"consecutive_empty_runs"`
+}
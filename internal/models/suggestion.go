@@ -0,0 +1,15 @@
+package models
+
+// Sources of search suggestions
+const (
+	SuggestionTitleTerm   = "title_term"
+	SuggestionChannel     = "channel"
+	SuggestionSearchQuery = "search_query"
+)
+
+// Suggestion is a completion for a partially typed search query
+type Suggestion struct {
+	Text  string  `json:"text"`
+	Kind  string  `json:"kind"`  // title_term, channel or search_query
+	Score float64 `json:"score"` // Frequency weighted by recency
+}
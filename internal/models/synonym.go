@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SynonymGroup lists words that search treats as equivalent: synonyms,
+// transliterations and other scripts ("cricket", "kriket", "क्रिकेट")
+type SynonymGroup struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string             `json:"name,omitempty" bson:"name,omitempty"`
+	Terms     []string           `json:"terms" bson:"terms"` // Words or phrases, at least two
+	Enabled   bool               `json:"enabled" bson:"enabled"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
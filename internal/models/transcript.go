@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TranscriptSegment is one timed caption line of a stored video
+type TranscriptSegment struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VideoID        string             `json:"video_id" bson:"video_id"`
+	Language       string             `json:"language" bson:"language"`
+	Start          float64            `json:"start" bson:"start"`       // Seconds from the start of the video
+	Duration       float64            `json:"duration" bson:"duration"` // Seconds
+	Text           string             `json:"text" bson:"text"`
+	NormalizedText string             `json:"-" bson:"normalized_text"`
+
+	// Copied from the video so search filters can narrow segments before grouping
+	ChannelID   string    `json:"-" bson:"channel_id"`
+	SearchQuery string    `json:"-" bson:"search_query"`
+	PublishedAt time.Time `json:"-" bson:"published_at"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// TranscriptMatch is a transcript segment that matched a search, with a
+// deep link that starts playback at the segment
+type TranscriptMatch struct {
+	Start    float64 `json:"start" bson:"start"`
+	Duration float64 `json:"duration" bson:"duration"`
+	Text     string  `json:"text" bson:"text"`
+	URL      string  `json:"url" bson:"-"`
+}
+
+// TranscriptSearchResult is a video found through its transcript
+type TranscriptSearchResult struct {
+	Video      `bson:",inline"`
+	Matches    []TranscriptMatch `json:"transcript_matches" bson:"transcript_matches"`
+	MatchCount int               `json:"transcript_match_count" bson:"transcript_match_count"`
+}
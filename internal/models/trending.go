@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category ID used for the overall (all categories) trending chart
+const TrendingAllCategories = "0"
+
+// TrendingEntry is a video's position on a trending chart
+type TrendingEntry struct {
+	Rank         int       `json:"rank" bson:"rank"`
+	VideoID      string    `json:"video_id" bson:"video_id"`
+	Title        string    `json:"title" bson:"title"`
+	ChannelID    string    `json:"channel_id" bson:"channel_id"`
+	ChannelTitle string    `json:"channel_title" bson:"channel_title"`
+	PublishedAt  time.Time `json:"published_at" bson:"published_at"`
+	ThumbnailURL Thumbnail `json:"thumbnails" bson:"thumbnails"`
+	ViewCount    int64     `json:"view_count" bson:"view_count"`
+	LikeCount    int64     `json:"like_count" bson:"like_count"`
+	PreviousRank int       `json:"previous_rank,omitempty" bson:"previous_rank,omitempty"` // 0 when new on the chart
+	Movement     int       `json:"movement" bson:"movement"`                               // Positions gained since the previous snapshot
+	IsNew        bool      `json:"is_new" bson:"is_new"`
+}
+
+// TrendingSnapshot is one fetch of YouTube's mostPopular chart for a region and category
+type TrendingSnapshot struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Region            string             `json:"region" bson:"region"`
+	CategoryID        string             `json:"category_id" bson:"category_id"`
+	FetchedAt         time.Time          `json:"fetched_at" bson:"fetched_at"`
+	PreviousFetchedAt *time.Time         `json:"previous_fetched_at,omitempty" bson:"previous_fetched_at,omitempty"`
+	Entries           []TrendingEntry    `json:"entries" bson:"entries"`
+}
@@ -16,8 +16,47 @@ type Video struct {
 	ChannelID    string             `json:"channel_id" bson:"channel_id"`
 	SearchQuery  string             `json:"search_query" bson:"search_query"` // Track origin query
 	ThumbnailURL Thumbnail          `json:"thumbnails" bson:"thumbnails"`
-	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+
+	// Kid-safety signals from Videos.List enrichment
+	MadeForKids   bool   `json:"made_for_kids" bson:"made_for_kids"`
+	ContentRating string `json:"content_rating,omitempty" bson:"content_rating,omitempty"` // YouTube ytRating
+	AgeRestricted bool   `json:"age_restricted" bson:"age_restricted"`
+	Enriched      bool   `json:"-" bson:"enriched"` // Videos.List details were applied; safety=strict only trusts these
+
+	// Format classification (short, standard, live, premiere)
+	DurationSeconds      int64  `json:"duration_seconds" bson:"duration_seconds"`
+	Format               string `json:"format,omitempty" bson:"format,omitempty"`
+	LiveBroadcastContent string `json:"live_broadcast_content,omitempty" bson:"live_broadcast_content,omitempty"` // none, live or upcoming
+
+	// Category from Videos.List, name resolved from the region's catalog
+	CategoryID   string `json:"category_id,omitempty" bson:"category_id,omitempty"`
+	CategoryName string `json:"category_name,omitempty" bson:"category_name,omitempty"`
+
+	// Language declared on YouTube (BCP 47) and the language stored for
+	// ?lang= (ISO 639-1 base code, detected from the text when undeclared).
+	// Stored as "lang": the text index treats a "language" field as a
+	// per-document stemming override and rejects unsupported values.
+	AudioLanguage string `json:"audio_language,omitempty" bson:"audio_language,omitempty"`
+	Language      string `json:"language,omitempty" bson:"lang,omitempty"`
+
+	// Live stream / premiere state, kept current by the broadcast tracker
+	Broadcast *Broadcast `json:"broadcast,omitempty" bson:"broadcast,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+
+	// Title trigrams for typo-tolerant search (see internal/search)
+	TitleNgrams []string `json:"-" bson:"title_ngrams,omitempty"`
+
+	// Markers of synonym groups with a variant in the title or description
+	SearchTerms []string `json:"-" bson:"search_terms,omitempty"`
+
+	// Normalized title and description matched by regex search (see search.Normalize)
+	Normalized *NormalizedText `json:"-" bson:"normalized,omitempty"`
+
+	// Text search relevance or fuzzy similarity (search results only, never stored)
+	Score     float64    `json:"score,omitempty" bson:"score,omitempty"`
+	Highlight *Highlight `json:"highlight,omitempty" bson:"-"`
 }
 
 type Thumbnail struct {
@@ -25,3 +64,9 @@ type Thumbnail struct {
 	Medium  string `json:"medium" bson:"medium"`
 	High    string `json:"high" bson:"high"`
 }
+
+// NormalizedText holds search.Normalize forms of a video's text
+type NormalizedText struct {
+	Title       string `bson:"title"`
+	Description string `bson:"description"`
+}
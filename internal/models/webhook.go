@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Webhook event types
+const (
+	WebhookEventVideoCreated = "video.created"
+	WebhookEventPing         = "ping" // Sent on demand to test a subscription
+)
+
+// Webhook delivery states
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook is a subscription to newly ingested videos. Empty filters match
+// every video; a video must pass each non-empty filter.
+type Webhook struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name       string             `json:"name" bson:"name"`
+	URL        string             `json:"url" bson:"url"`
+	Secret     string             `json:"-" bson:"secret"`                                    // HMAC-SHA256 signing key, only returned on create
+	Queries    []string           `json:"queries,omitempty" bson:"queries,omitempty"`         // Search queries the video was fetched for
+	ChannelIDs []string           `json:"channel_ids,omitempty" bson:"channel_ids,omitempty"` // Any of these channels
+	Keywords   []string           `json:"keywords,omitempty" bson:"keywords,omitempty"`       // Any keyword in the title or description
+	Enabled    bool               `json:"enabled" bson:"enabled"`
+
+	ConsecutiveFailures int        `json:"consecutive_failures" bson:"consecutive_failures"` // Failed attempts since the last success
+	DisabledAt          *time.Time `json:"disabled_at,omitempty" bson:"disabled_at,omitempty"`
+	DisabledReason      string     `json:"disabled_reason,omitempty" bson:"disabled_reason,omitempty"`
+	LastDeliveredAt     *time.Time `json:"last_delivered_at,omitempty" bson:"last_delivered_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+// WebhookDelivery is one event sent to a webhook, with every attempt made
+type WebhookDelivery struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	WebhookID     primitive.ObjectID `json:"webhook_id" bson:"webhook_id"`
+	Event         string             `json:"event" bson:"event"`
+	VideoID       string             `json:"video_id,omitempty" bson:"video_id,omitempty"` // YouTube video ID
+	Payload       string             `json:"payload" bson:"payload"`                       // JSON body, identical on every attempt
+	Status        string             `json:"status" bson:"status"`
+	Attempts      []WebhookAttempt   `json:"attempts" bson:"attempts"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty" bson:"next_attempt_at,omitempty"` // Pending deliveries only
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// WebhookAttempt is the outcome of one HTTP request of a delivery
+type WebhookAttempt struct {
+	AttemptedAt time.Time `json:"attempted_at" bson:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty" bson:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty" bson:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms" bson:"duration_ms"`
+}
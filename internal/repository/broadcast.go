@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// GetActiveBroadcasts returns videos whose broadcast is upcoming or live
+func (r *VideoRepository) GetActiveBroadcasts(limit int) ([]models.Video, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"broadcast.state": bson.M{"$in": []string{models.BroadcastUpcoming, models.BroadcastLive}}}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "broadcast.checked_at", Value: 1}}) // Least recently checked first
+	findOptions.SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active broadcasts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var videos []models.Video
+	if err = cursor.All(ctx, &videos); err != nil {
+		return nil, fmt.Errorf("failed to decode active broadcasts: %w", err)
+	}
+
+	return videos, nil
+}
+
+// UpdateBroadcast stores a new broadcast state for a video
+func (r *VideoRepository) UpdateBroadcast(videoID string, broadcast *models.Broadcast) error {
+	return r.setBroadcast(videoID, broadcast, bson.M{})
+}
+
+// CompleteBroadcast stores the final state of a broadcast that has ended,
+// with the format and duration of its recording
+func (r *VideoRepository) CompleteBroadcast(videoID string, broadcast *models.Broadcast, format string, durationSeconds int64) error {
+	return r.setBroadcast(videoID, broadcast, bson.M{"format": format, "duration_seconds": durationSeconds})
+}
+
+func (r *VideoRepository) setBroadcast(videoID string, broadcast *models.Broadcast, fields bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fields["broadcast"] = broadcast
+	fields["live_broadcast_content"] = broadcast.LiveBroadcastContent()
+	fields["updated_at"] = time.Now()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"video_id": videoID}, bson.M{"$set": fields})
+	if err != nil {
+		return fmt.Errorf("failed to update broadcast: %w", err)
+	}
+
+	return nil
+}
+
+// GetBroadcasts lists broadcasts in a state, optionally for one search query.
+// Live broadcasts are ordered by most recent start, upcoming ones by soonest start.
+func (r *VideoRepository) GetBroadcasts(state, query string, limit int) ([]models.Video, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"broadcast.state": state}
+	if query != "" {
+		filter["search_query"] = query
+	}
+
+	findOptions := options.Find()
+	if state == models.BroadcastUpcoming {
+		findOptions.SetSort(bson.D{{Key: "broadcast.scheduled_start", Value: 1}})
+	} else {
+		findOptions.SetSort(bson.D{{Key: "broadcast.actual_start", Value: -1}})
+	}
+	findOptions.SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find broadcasts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	videos := []models.Video{}
+	if err = cursor.All(ctx, &videos); err != nil {
+		return nil, fmt.Errorf("failed to decode broadcasts: %w", err)
+	}
+
+	return videos, nil
+}
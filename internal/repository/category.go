@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type CategoryRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewCategoryRepository(db *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		db:         db,
+		collection: db.Collection("video_categories"),
+	}
+}
+
+// UpsertMany stores the latest catalog entries of a region
+func (r *CategoryRepository) UpsertMany(categories []*models.VideoCategory) error {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(categories))
+	for _, category := range categories {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"region": category.Region, "category_id": category.CategoryID}).
+			SetReplacement(category).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to store video categories: %w", err)
+	}
+
+	return nil
+}
+
+// ListByRegion returns a region's catalog ordered by category ID
+func (r *CategoryRepository) ListByRegion(region string) ([]models.VideoCategory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "category_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"region": region}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find video categories: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var categories []models.VideoCategory
+	if err = cursor.All(ctx, &categories); err != nil {
+		return nil, fmt.Errorf("failed to decode video categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// NameMap returns category names of a region keyed by category ID
+func (r *CategoryRepository) NameMap(region string) (map[string]string, error) {
+	categories, err := r.ListByRegion(region)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.CategoryID] = category.Title
+	}
+	return names, nil
+}
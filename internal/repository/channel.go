@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type ChannelRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewChannelRepository(db *mongo.Database) *ChannelRepository {
+	return &ChannelRepository{
+		db:         db,
+		collection: db.Collection("channels"),
+	}
+}
+
+// UpsertMany stores fresh channel details, keeping the original created_at
+func (r *ChannelRepository) UpsertMany(channels []*models.Channel) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(channels))
+	for _, channel := range channels {
+		update := bson.M{
+			"$set": bson.M{
+				"title":                   channel.Title,
+				"description":             channel.Description,
+				"custom_url":              channel.CustomURL,
+				"country":                 channel.Country,
+				"avatar_url":              channel.AvatarURL,
+				"subscriber_count":        channel.SubscriberCount,
+				"hidden_subscriber_count": channel.HiddenSubscriberCount,
+				"video_count":             channel.VideoCount,
+				"view_count":              channel.ViewCount,
+				"published_at":            channel.PublishedAt,
+				"refreshed_at":            channel.RefreshedAt,
+				"updated_at":              now,
+			},
+			"$setOnInsert": bson.M{"created_at": now},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"channel_id": channel.ChannelID}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to store channels: %w", err)
+	}
+
+	return nil
+}
+
+// StaleChannelIDs returns channels referenced by stored videos that were
+// never fetched or were last refreshed before the cutoff
+func (r *ChannelRepository) StaleChannelIDs(refreshedBefore time.Time, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"channel_id": bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$channel_id"}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.collection.Name(),
+			"localField":   "_id",
+			"foreignField": "channel_id",
+			"as":           "channel",
+		}}},
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"channel": bson.M{"$size": 0}},
+			bson.M{"channel.refreshed_at": bson.M{"$lt": refreshedBefore}},
+		}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.db.Collection("videos").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale channels: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ChannelID string `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode stale channels: %w", err)
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ChannelID)
+	}
+	return ids, nil
+}
+
+func (r *ChannelRepository) GetByChannelID(channelID string) (*models.Channel, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var channel models.Channel
+	err := r.collection.FindOne(ctx, bson.M{"channel_id": channelID}).Decode(&channel)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Channel not found
+		}
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	return &channel, nil
+}
+
+func (r *ChannelRepository) GetPaginated(pagination Pagination, sortBy string) ([]models.Channel, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	channels, info, err := findPage[models.Channel](ctx, r.collection, bson.M{}, r.buildSortOptions(sortBy), sortBy, pagination, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find channels: %w", err)
+	}
+
+	return channels, info, nil
+}
+
+func (r *ChannelRepository) buildSortOptions(sortBy string) bson.D {
+	switch sortBy {
+	case "name":
+		return bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}
+	case "videos":
+		return bson.D{{Key: "video_count", Value: -1}, {Key: "_id", Value: -1}}
+	case "views":
+		return bson.D{{Key: "view_count", Value: -1}, {Key: "_id", Value: -1}}
+	default:
+		return bson.D{{Key: "subscriber_count", Value: -1}, {Key: "_id", Value: -1}}
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type CommentRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewCommentRepository(db *mongo.Database) *CommentRepository {
+	return &CommentRepository{
+		db:         db,
+		collection: db.Collection("comments"),
+	}
+}
+
+// UpsertMany stores comments, refreshing any that were fetched before
+func (r *CommentRepository) UpsertMany(comments []*models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(comments))
+	for _, comment := range comments {
+		comment.FetchedAt = time.Now()
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"comment_id": comment.CommentID}).
+			SetReplacement(comment).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to store comments: %w", err)
+	}
+
+	return nil
+}
+
+// GetByVideoID returns a page of a video's comments in relevance order
+func (r *CommentRepository) GetByVideoID(videoID string, pagination Pagination) ([]models.Comment, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"video_id": videoID}
+	sort := bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}}
+
+	comments, info, err := findPage[models.Comment](ctx, r.collection, filter, sort, "rank", pagination, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find comments: %w", err)
+	}
+
+	return comments, info, nil
+}
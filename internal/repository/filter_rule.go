@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type FilterRuleRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	rejections *mongo.Collection
+}
+
+func NewFilterRuleRepository(db *mongo.Database) *FilterRuleRepository {
+	return &FilterRuleRepository{
+		db:         db,
+		collection: db.Collection("filter_rules"),
+		rejections: db.Collection("rejected_videos"),
+	}
+}
+
+func (r *FilterRuleRepository) List() ([]models.FilterRule, error) {
+	return r.find(bson.M{})
+}
+
+// ListEnabled returns the rules the ingestion filter should evaluate
+func (r *FilterRuleRepository) ListEnabled() ([]models.FilterRule, error) {
+	return r.find(bson.M{"enabled": true})
+}
+
+func (r *FilterRuleRepository) find(filter bson.M) ([]models.FilterRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find filter rules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := []models.FilterRule{}
+	if err = cursor.All(ctx, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode filter rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *FilterRuleRepository) GetByID(id primitive.ObjectID) (*models.FilterRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rule models.FilterRule
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&rule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Rule not found
+		}
+		return nil, fmt.Errorf("failed to get filter rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+func (r *FilterRuleRepository) Create(rule *models.FilterRule) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, rule)
+	if err != nil {
+		return fmt.Errorf("failed to create filter rule: %w", err)
+	}
+	rule.ID = result.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
+// Update replaces a rule, returning false when it does not exist
+func (r *FilterRuleRepository) Update(rule *models.FilterRule) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rule.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"name":       rule.Name,
+		"type":       rule.Type,
+		"query":      rule.Query,
+		"fields":     rule.Fields,
+		"values":     rule.Values,
+		"enabled":    rule.Enabled,
+		"updated_at": rule.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": rule.ID}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to update filter rule: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes a rule, returning false when it does not exist
+func (r *FilterRuleRepository) Delete(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete filter rule: %w", err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// RecordRejection logs a dropped video. Videos are re-fetched while they
+// stay inside the search window, so repeat rejections update one entry.
+func (r *FilterRuleRepository) RecordRejection(rejection *models.RejectedVideo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"title":         rejection.Title,
+			"channel_id":    rejection.ChannelID,
+			"channel_title": rejection.ChannelTitle,
+			"search_query":  rejection.SearchQuery,
+			"rule_id":       rejection.RuleID,
+			"rule_name":     rejection.RuleName,
+			"rule_type":     rejection.RuleType,
+			"reason":        rejection.Reason,
+			"last_seen_at":  now,
+		},
+		"$setOnInsert": bson.M{"rejected_at": now},
+	}
+
+	_, err := r.rejections.UpdateOne(ctx, bson.M{"video_id": rejection.VideoID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record rejected video: %w", err)
+	}
+
+	return nil
+}
+
+// GetRejections returns dropped videos, newest first, optionally for a single rule
+func (r *FilterRuleRepository) GetRejections(ruleID *primitive.ObjectID, pagination Pagination) ([]models.RejectedVideo, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if ruleID != nil {
+		filter["rule_id"] = *ruleID
+	}
+	sort := bson.D{{Key: "last_seen_at", Value: -1}, {Key: "_id", Value: -1}}
+
+	rejections, info, err := findPage[models.RejectedVideo](ctx, r.rejections, filter, sort, "latest", pagination, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find rejected videos: %w", err)
+	}
+
+	return rejections, info, nil
+}
@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidCursor is returned for cursor tokens that are malformed or were
+// issued for a different sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Pagination selects a page either by number (skip/limit plus a total
+// count) or by an opaque cursor (keyset pagination, no count). Cursor
+// pages stay stable while new videos are inserted and cost the same at
+// any depth.
+type Pagination struct {
+	Page     int
+	PageSize int
+	Cursor   *Cursor
+}
+
+// PageInfo describes the page returned by a paginated query
+type PageInfo struct {
+	Total          *int64 // Only counted for page-number requests
+	NextCursor     string
+	PreviousCursor string
+}
+
+// Cursor is the decoded form of a pagination token: the sort mode and the
+// sort key values (ending with _id) of the document it points at
+type Cursor struct {
+	Sort     string `bson:"s"`
+	Values   bson.A `bson:"v"`
+	Backward bool   `bson:"b,omitempty"` // Page before the document instead of after it
+}
+
+// Encode returns the opaque URL-safe token for the cursor
+func (c *Cursor) Encode() string {
+	data, err := bson.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := bson.Unmarshal(data, &cursor); err != nil || len(cursor.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// withTieBreaker appends _id to a sort so documents with equal sort keys
+// keep a stable order (required for keyset pagination)
+func withTieBreaker(sort bson.D) bson.D {
+	for _, key := range sort {
+		if key.Key == "_id" {
+			return sort
+		}
+	}
+
+	direction := 1
+	if len(sort) > 0 && sort[0].Value == -1 {
+		direction = -1
+	}
+	return append(append(bson.D{}, sort...), bson.E{Key: "_id", Value: direction})
+}
+
+// keysetCondition matches documents after (or before) the cursor position
+// in sort order: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... MongoDB sorts
+// null and missing values below everything else, but comparison operators
+// never match them, so null is handled explicitly on both sides.
+func keysetCondition(sort bson.D, values bson.A, backward bool) bson.M {
+	clauses := make([]bson.M, 0, len(sort))
+	for i, key := range sort {
+		clause := bson.M{}
+		for j := 0; j < i; j++ {
+			clause[sort[j].Key] = values[j]
+		}
+
+		// Whether documents past the cursor have larger values of this key
+		ascending := (key.Value == -1) == backward
+		switch {
+		case values[i] == nil && ascending:
+			clause[key.Key] = bson.M{"$ne": nil}
+		case values[i] == nil:
+			continue // Nothing sorts below null
+		case ascending:
+			clause[key.Key] = bson.M{"$gt": values[i]}
+		default:
+			clause["$or"] = []bson.M{{key.Key: bson.M{"$lt": values[i]}}, {key.Key: nil}}
+		}
+		clauses = append(clauses, clause)
+	}
+	return bson.M{"$or": clauses}
+}
+
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, len(sort))
+	for i, key := range sort {
+		direction := 1
+		if key.Value == 1 {
+			direction = -1
+		}
+		reversed[i] = bson.E{Key: key.Key, Value: direction}
+	}
+	return reversed
+}
+
+// cursorFor builds a cursor pointing at doc
+func cursorFor(doc bson.Raw, sort bson.D, sortName string, backward bool) string {
+	values := make(bson.A, len(sort))
+	for i, key := range sort {
+		value, err := doc.LookupErr(strings.Split(key.Key, ".")...)
+		if err != nil {
+			values[i] = nil // Missing fields sort as null
+			continue
+		}
+		values[i] = value
+	}
+	return (&Cursor{Sort: sortName, Values: values, Backward: backward}).Encode()
+}
+
+// findPage runs a paginated Find. sortName identifies the sort mode in
+// cursors so a token can't be replayed against a different order.
+// findOptions may carry a projection.
+func findPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, sort bson.D, sortName string, pagination Pagination, findOptions *options.FindOptions) ([]T, *PageInfo, error) {
+	sort = withTieBreaker(sort)
+	if findOptions == nil {
+		findOptions = options.Find()
+	}
+
+	if pagination.Cursor == nil {
+		total, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count documents: %w", err)
+		}
+
+		skip := (pagination.Page - 1) * pagination.PageSize
+		findOptions.SetSort(sort)
+		findOptions.SetSkip(int64(skip))
+		findOptions.SetLimit(int64(pagination.PageSize))
+
+		docs, err := findRaw(ctx, collection, filter, findOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decodePage[T](docs, offsetPageInfo(docs, total, sort, sortName, pagination))
+	}
+
+	cursor := pagination.Cursor
+	if cursor.Sort != sortName || len(cursor.Values) != len(sort) {
+		return nil, nil, ErrInvalidCursor
+	}
+
+	querySort := sort
+	if cursor.Backward {
+		querySort = reverseSort(sort)
+	}
+
+	condition := keysetCondition(sort, cursor.Values, cursor.Backward)
+	if len(filter) > 0 {
+		condition = bson.M{"$and": []bson.M{filter, condition}}
+	}
+
+	// One extra document tells whether another page follows
+	findOptions.SetSort(querySort)
+	findOptions.SetLimit(int64(pagination.PageSize + 1))
+
+	docs, err := findRaw(ctx, collection, condition, findOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, info := keysetPageInfo(docs, sort, sortName, pagination)
+	return decodePage[T](docs, info)
+}
+
+// aggregatePage paginates the documents produced by an aggregation
+// pipeline, like findPage does for a filter. The pipeline must not sort or
+// limit; the sort keys are read from its output documents.
+func aggregatePage[T any](ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, sort bson.D, sortName string, pagination Pagination) ([]T, *PageInfo, error) {
+	sort = withTieBreaker(sort)
+	pipeline = append(mongo.Pipeline{}, pipeline...)
+
+	if pagination.Cursor == nil {
+		skip := (pagination.Page - 1) * pagination.PageSize
+		pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+			"results": bson.A{
+				bson.M{"$sort": sort},
+				bson.M{"$skip": int64(skip)},
+				bson.M{"$limit": int64(pagination.PageSize)},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}})
+
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to aggregate documents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		var facets []struct {
+			Results []bson.Raw `bson:"results"`
+			Total   []struct {
+				Count int64 `bson:"count"`
+			} `bson:"total"`
+		}
+		if err := cursor.All(ctx, &facets); err != nil {
+			return nil, nil, fmt.Errorf("failed to read documents: %w", err)
+		}
+
+		var docs []bson.Raw
+		var total int64
+		if len(facets) > 0 {
+			docs = facets[0].Results
+			if len(facets[0].Total) > 0 {
+				total = facets[0].Total[0].Count
+			}
+		}
+		return decodePage[T](docs, offsetPageInfo(docs, total, sort, sortName, pagination))
+	}
+
+	cursor := pagination.Cursor
+	if cursor.Sort != sortName || len(cursor.Values) != len(sort) {
+		return nil, nil, ErrInvalidCursor
+	}
+
+	querySort := sort
+	if cursor.Backward {
+		querySort = reverseSort(sort)
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: keysetCondition(sort, cursor.Values, cursor.Backward)}},
+		bson.D{{Key: "$sort", Value: querySort}},
+		bson.D{{Key: "$limit", Value: int64(pagination.PageSize + 1)}},
+	)
+
+	results, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to aggregate documents: %w", err)
+	}
+	defer results.Close(ctx)
+
+	var docs []bson.Raw
+	for results.Next(ctx) {
+		docs = append(docs, append(bson.Raw{}, results.Current...))
+	}
+	if err := results.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read documents: %w", err)
+	}
+
+	docs, info := keysetPageInfo(docs, sort, sortName, pagination)
+	return decodePage[T](docs, info)
+}
+
+// offsetPageInfo describes a page fetched by page number. Cursors let
+// clients switch to keyset pagination after the first page.
+func offsetPageInfo(docs []bson.Raw, total int64, sort bson.D, sortName string, pagination Pagination) *PageInfo {
+	info := &PageInfo{Total: &total}
+	if len(docs) > 0 {
+		skip := (pagination.Page - 1) * pagination.PageSize
+		if int64(skip+len(docs)) < total {
+			info.NextCursor = cursorFor(docs[len(docs)-1], sort, sortName, false)
+		}
+		if pagination.Page > 1 {
+			info.PreviousCursor = cursorFor(docs[0], sort, sortName, true)
+		}
+	}
+	return info
+}
+
+// keysetPageInfo trims a keyset query's result (fetched with one extra
+// document) to the page, in sort order, and describes it
+func keysetPageInfo(docs []bson.Raw, sort bson.D, sortName string, pagination Pagination) ([]bson.Raw, *PageInfo) {
+	cursor := pagination.Cursor
+	info := &PageInfo{}
+
+	hasMore := len(docs) > pagination.PageSize
+	if hasMore {
+		docs = docs[:pagination.PageSize]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+
+	if len(docs) > 0 {
+		first, last := docs[0], docs[len(docs)-1]
+		if !cursor.Backward || hasMore {
+			info.PreviousCursor = cursorFor(first, sort, sortName, true)
+		}
+		if cursor.Backward || hasMore {
+			info.NextCursor = cursorFor(last, sort, sortName, false)
+		}
+	}
+	return docs, info
+}
+
+func decodePage[T any](docs []bson.Raw, info *PageInfo) ([]T, *PageInfo, error) {
+	items := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		var item T
+		if err := bson.Unmarshal(doc, &item); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, info, nil
+}
+
+func findRaw(ctx context.Context, collection *mongo.Collection, filter bson.M, findOptions *options.FindOptions) ([]bson.Raw, error) {
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.Raw
+	for cursor.Next(ctx) {
+		docs = append(docs, append(bson.Raw{}, cursor.Current...))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read documents: %w", err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	published := primitive.NewDateTimeFromTime(time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"latest", Cursor{Sort: "latest", Values: bson.A{published, id}}},
+		{"backward", Cursor{Sort: "latest", Values: bson.A{published, id}, Backward: true}},
+		{"title", Cursor{Sort: "title", Values: bson.A{"Café & Crème ?", id}}},
+		{"missing sort key", Cursor{Sort: "shortest", Values: bson.A{nil, published, id}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.cursor.Encode()
+			if token == "" {
+				t.Fatal("Encode returned an empty token")
+			}
+			for _, r := range token {
+				if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+					t.Fatalf("token %q is not URL-safe", token)
+				}
+			}
+
+			decoded, err := DecodeCursor(token)
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+			if !reflect.DeepEqual(*decoded, tt.cursor) {
+				t.Errorf("DecodeCursor(Encode()) = %#v, want %#v", *decoded, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	empty := (&Cursor{Sort: "latest"}).Encode()
+
+	for _, token := range []string{"", "not base64!", "aGVsbG8", empty} {
+		if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	latest := bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
+	shortest := bson.D{{Key: "duration_seconds", Value: 1}, {Key: "_id", Value: -1}}
+
+	tests := []struct {
+		name     string
+		sort     bson.D
+		values   bson.A
+		backward bool
+		want     bson.M
+	}{
+		{
+			// Descending order ends with null, which $lt never matches
+			name:   "forward descending",
+			sort:   latest,
+			values: bson.A{"t", "id"},
+			want: bson.M{"$or": []bson.M{
+				{"$or": []bson.M{{"published_at": bson.M{"$lt": "t"}}, {"published_at": nil}}},
+				{"published_at": "t", "$or": []bson.M{{"_id": bson.M{"$lt": "id"}}, {"_id": nil}}},
+			}},
+		},
+		{
+			name:     "backward descending",
+			sort:     latest,
+			values:   bson.A{"t", "id"},
+			backward: true,
+			want: bson.M{"$or": []bson.M{
+				{"published_at": bson.M{"$gt": "t"}},
+				{"published_at": "t", "_id": bson.M{"$gt": "id"}},
+			}},
+		},
+		{
+			// Every non-null value sorts after a null cursor position
+			name:   "forward ascending from null",
+			sort:   shortest,
+			values: bson.A{nil, "id"},
+			want: bson.M{"$or": []bson.M{
+				{"duration_seconds": bson.M{"$ne": nil}},
+				{"duration_seconds": nil, "$or": []bson.M{{"_id": bson.M{"$lt": "id"}}, {"_id": nil}}},
+			}},
+		},
+		{
+			// Nothing sorts before null, so only the tie-breaker can move back
+			name:     "backward ascending from null",
+			sort:     shortest,
+			values:   bson.A{nil, "id"},
+			backward: true,
+			want: bson.M{"$or": []bson.M{
+				{"duration_seconds": nil, "_id": bson.M{"$gt": "id"}},
+			}},
+		},
+		{
+			name:   "forward ascending",
+			sort:   shortest,
+			values: bson.A{int64(60), "id"},
+			want: bson.M{"$or": []bson.M{
+				{"duration_seconds": bson.M{"$gt": int64(60)}},
+				{"duration_seconds": int64(60), "$or": []bson.M{{"_id": bson.M{"$lt": "id"}}, {"_id": nil}}},
+			}},
+		},
+		{
+			// Null values come before the cursor in ascending order
+			name:     "backward ascending",
+			sort:     shortest,
+			values:   bson.A{int64(60), "id"},
+			backward: true,
+			want: bson.M{"$or": []bson.M{
+				{"$or": []bson.M{{"duration_seconds": bson.M{"$lt": int64(60)}}, {"duration_seconds": nil}}},
+				{"duration_seconds": int64(60), "_id": bson.M{"$gt": "id"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keysetCondition(tt.sort, tt.values, tt.backward); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keysetCondition() =\n  %v\nwant\n  %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTieBreaker(t *testing.T) {
+	tests := []struct {
+		name string
+		sort bson.D
+		want bson.D
+	}{
+		{
+			name: "descending",
+			sort: bson.D{{Key: "published_at", Value: -1}},
+			want: bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name: "ascending",
+			sort: bson.D{{Key: "title", Value: 1}},
+			want: bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "already unique",
+			sort: bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+			want: bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withTieBreaker(tt.sort); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withTieBreaker() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorFor(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc, err := bson.Marshal(bson.M{"_id": id, "title": "Final", "stats": bson.M{"views": int64(7)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort := bson.D{{Key: "stats.views", Value: -1}, {Key: "duration_seconds", Value: 1}, {Key: "_id", Value: -1}}
+	cursor, err := DecodeCursor(cursorFor(doc, sort, "views", true))
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+
+	if cursor.Sort != "views" || !cursor.Backward {
+		t.Errorf("cursor = %+v, want sort views, backward", cursor)
+	}
+	want := bson.A{int64(7), nil, id}
+	if !reflect.DeepEqual(cursor.Values, want) {
+		t.Errorf("Values = %#v, want %#v", cursor.Values, want)
+	}
+}
+
+func TestKeysetPageInfo(t *testing.T) {
+	sort := bson.D{{Key: "n", Value: 1}, {Key: "_id", Value: 1}}
+	docs := func(ns ...int32) []bson.Raw {
+		raws := make([]bson.Raw, 0, len(ns))
+		for _, n := range ns {
+			raw, _ := bson.Marshal(bson.D{{Key: "_id", Value: n}, {Key: "n", Value: n}})
+			raws = append(raws, raw)
+		}
+		return raws
+	}
+	position := func(token string) int32 {
+		if token == "" {
+			return 0
+		}
+		cursor, err := DecodeCursor(token)
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		return cursor.Values[0].(int32)
+	}
+
+	tests := []struct {
+		name               string
+		backward           bool
+		fetched            []bson.Raw
+		wantFirst, wantLen int32
+		wantNext, wantPrev int32 // Cursor positions, 0 for none
+	}{
+		{name: "forward with more", fetched: docs(4, 5, 6), wantFirst: 4, wantLen: 2, wantNext: 5, wantPrev: 4},
+		{name: "forward last page", fetched: docs(4, 5), wantFirst: 4, wantLen: 2, wantPrev: 4},
+		// Backward queries read in reverse order; the page is put back in sort order
+		{name: "backward with more", backward: true, fetched: docs(3, 2, 1), wantFirst: 2, wantLen: 2, wantNext: 3, wantPrev: 2},
+		{name: "backward first page", backward: true, fetched: docs(2, 1), wantFirst: 1, wantLen: 2, wantNext: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination := Pagination{PageSize: 2, Cursor: &Cursor{Sort: "n", Values: bson.A{0, 0}, Backward: tt.backward}}
+			page, info := keysetPageInfo(tt.fetched, sort, "n", pagination)
+
+			if int32(len(page)) != tt.wantLen || page[0].Lookup("n").Int32() != tt.wantFirst {
+				t.Errorf("page starts at %d with %d documents, want %d with %d", page[0].Lookup("n").Int32(), len(page), tt.wantFirst, tt.wantLen)
+			}
+			if got := position(info.NextCursor); got != tt.wantNext {
+				t.Errorf("next cursor at %d, want %d", got, tt.wantNext)
+			}
+			if got := position(info.PreviousCursor); got != tt.wantPrev {
+				t.Errorf("previous cursor at %d, want %d", got, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type SavedSearchRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	matches    *mongo.Collection
+}
+
+func NewSavedSearchRepository(db *mongo.Database) *SavedSearchRepository {
+	return &SavedSearchRepository{
+		db:         db,
+		collection: db.Collection("saved_searches"),
+		matches:    db.Collection("saved_search_matches"),
+	}
+}
+
+func (r *SavedSearchRepository) List() ([]models.SavedSearch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saved searches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	searches := []models.SavedSearch{}
+	if err = cursor.All(ctx, &searches); err != nil {
+		return nil, fmt.Errorf("failed to decode saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+func (r *SavedSearchRepository) GetByID(id primitive.ObjectID) (*models.SavedSearch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var savedSearch models.SavedSearch
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&savedSearch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Saved search not found
+		}
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+
+	return &savedSearch, nil
+}
+
+func (r *SavedSearchRepository) Create(savedSearch *models.SavedSearch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	savedSearch.CreatedAt = now
+	savedSearch.UpdatedAt = now
+	savedSearch.LastCheckedAt = now // Only videos stored from now on are new
+
+	result, err := r.collection.InsertOne(ctx, savedSearch)
+	if err != nil {
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+	savedSearch.ID = result.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
+// Update replaces the query, filters and sort, returning false when the
+// saved search does not exist. Matches of the previous query are kept.
+func (r *SavedSearchRepository) Update(savedSearch *models.SavedSearch) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	savedSearch.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"name":       savedSearch.Name,
+		"query":      savedSearch.Query,
+		"filters":    savedSearch.Filters,
+		"sort":       savedSearch.Sort,
+		"updated_at": savedSearch.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": savedSearch.ID}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes a saved search and its matches, returning false when it
+// does not exist
+func (r *SavedSearchRepository) Delete(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	if _, err := r.matches.DeleteMany(ctx, bson.M{"saved_search_id": id}); err != nil {
+		return true, fmt.Errorf("failed to delete saved search matches: %w", err)
+	}
+	return true, nil
+}
+
+// RecordMatches stores matched videos, ignoring ones already recorded
+func (r *SavedSearchRepository) RecordMatches(savedSearchID primitive.ObjectID, videoIDs []primitive.ObjectID) error {
+	if len(videoIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(videoIDs))
+	for _, videoID := range videoIDs {
+		filter := bson.M{"saved_search_id": savedSearchID, "video_id": videoID}
+		update := bson.M{"$setOnInsert": bson.M{"matched_at": now}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	if _, err := r.matches.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record saved search matches: %w", err)
+	}
+	return nil
+}
+
+// CountNewMatches counts matches recorded after the saved search's last check
+func (r *SavedSearchRepository) CountNewMatches(savedSearch *models.SavedSearch) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.matches.CountDocuments(ctx, bson.M{
+		"saved_search_id": savedSearch.ID,
+		"matched_at":      bson.M{"$gt": savedSearch.LastCheckedAt},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count saved search matches: %w", err)
+	}
+	return count, nil
+}
+
+// NewMatches returns about limit matches recorded after the last check,
+// oldest first, and whether more remain. Matches recorded together share
+// matched_at, which is the check cursor, so a page never splits them.
+func (r *SavedSearchRepository) NewMatches(savedSearch *models.SavedSearch, limit int) ([]models.SavedSearchMatch, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"saved_search_id": savedSearch.ID,
+		"matched_at":      bson.M{"$gt": savedSearch.LastCheckedAt},
+	}
+	matches, err := r.findMatches(ctx, filter, int64(limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(matches) <= limit {
+		return matches, false, nil
+	}
+
+	if page := completeBatches(matches, limit); len(page) > 0 {
+		return page, true, nil
+	}
+
+	// A single batch larger than the limit is returned whole
+	boundary := matches[limit].MatchedAt
+	filter["matched_at"] = boundary
+	batch, err := r.findMatches(ctx, filter, 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	filter["matched_at"] = bson.M{"$gt": boundary}
+	later, err := r.matches.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to count saved search matches: %w", err)
+	}
+	return batch, later > 0, nil
+}
+
+// completeBatches returns the first limit of more than limit matches,
+// without the trailing batch that continues past the limit. It is empty
+// when one batch fills the whole page.
+func completeBatches(matches []models.SavedSearchMatch, limit int) []models.SavedSearchMatch {
+	boundary := matches[limit].MatchedAt
+	page := matches[:limit]
+	for len(page) > 0 && page[len(page)-1].MatchedAt.Equal(boundary) {
+		page = page[:len(page)-1]
+	}
+	return page
+}
+
+func (r *SavedSearchRepository) findMatches(ctx context.Context, filter bson.M, limit int64) ([]models.SavedSearchMatch, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "matched_at", Value: 1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+
+	cursor, err := r.matches.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saved search matches: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	matches := []models.SavedSearchMatch{}
+	if err = cursor.All(ctx, &matches); err != nil {
+		return nil, fmt.Errorf("failed to decode saved search matches: %w", err)
+	}
+	return matches, nil
+}
+
+// MarkChecked moves the saved search's last check forward (never back)
+func (r *SavedSearchRepository) MarkChecked(id primitive.ObjectID, checkedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$max": bson.M{"last_checked_at": checkedAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update saved search check time: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestCompleteBatches(t *testing.T) {
+	start := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+
+	// Each digit is one match; equal digits were recorded together
+	matches := func(batches string) []models.SavedSearchMatch {
+		var result []models.SavedSearchMatch
+		for _, batch := range batches {
+			result = append(result, models.SavedSearchMatch{MatchedAt: start.Add(time.Duration(batch-'0') * time.Minute)})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		batches string
+		limit   int
+		want    int
+	}{
+		{name: "limit between batches", batches: "1122", limit: 2, want: 2},
+		{name: "batch crosses the limit", batches: "11222", limit: 3, want: 2},
+		{name: "one match per batch", batches: "1234", limit: 3, want: 3},
+		{name: "one batch fills the page", batches: "1111", limit: 3, want: 0},
+		{name: "first batch crosses the limit", batches: "11122", limit: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := completeBatches(matches(tt.batches), tt.limit); len(got) != tt.want {
+				t.Errorf("completeBatches(%s, %d) returned %d matches, want %d", tt.batches, tt.limit, len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"fampay-youtube-api/internal/search"
+)
+
+// Tokens shorter than this are usually partial words typed into a search
+// box; the text index only matches whole (stemmed) words.
+const minTextTokenLength = 3
+
+// compiledQuery is a parsed search query translated to Mongo conditions
+type compiledQuery struct {
+	textSearch string   // $text search string; empty when no term can use the index
+	fuzzyText  string   // Words compared by trigram similarity in fuzzy mode
+	regexTerms []bson.M // Prefix regex of every positive word; all must match
+	conditions []bson.M // Field qualifiers, dates, exclusions and OR groups
+}
+
+// compileQuery sends positive words and phrases to the weighted text index
+// and everything else to regular conditions. $text matches any of its bare
+// words, so every positive word is also required by a prefix regex and
+// $text only narrows the candidates and ranks them; quoted phrases are
+// required by $text itself. $text may appear only once, at the top level
+// and never negated, so words inside OR groups and exclusions use prefix
+// regexes alone. Synonym terms use their group's search_terms marker and
+// literal variants in $text, and require the marker or any variant.
+func compileQuery(query *search.Query) compiledQuery {
+	var compiled compiledQuery
+	var textParts []string
+
+	for _, clause := range query.Clauses {
+		if synonym, ok := clause.(search.Synonym); ok && synonym.Term.Field == search.FieldAny {
+			textParts = append(textParts, synonymTextParts(synonym)...)
+			compiled.regexTerms = append(compiled.regexTerms, nodeCondition(synonym))
+			continue
+		}
+
+		term, ok := clause.(search.Term)
+		if !ok || term.Field != search.FieldAny {
+			compiled.conditions = append(compiled.conditions, nodeCondition(clause))
+			continue
+		}
+
+		if textIndexable(term) {
+			textParts = append(textParts, textSearchPart(term))
+			if term.Phrase {
+				continue
+			}
+		}
+		compiled.regexTerms = append(compiled.regexTerms, nodeCondition(term))
+	}
+
+	compiled.textSearch = strings.Join(textParts, " ")
+	compiled.fuzzyText = query.Text()
+	return compiled
+}
+
+// textSearchPart writes a term in $text syntax. Only phrases the user
+// quoted are quoted, since $text phrases are matched without stemming.
+func textSearchPart(term search.Term) string {
+	value := strings.ReplaceAll(term.Value, `"`, "")
+	if term.Phrase {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// synonymTextParts returns the $text words of a synonym group: its marker,
+// which matches videos indexed for the group, and the words of every
+// variant, which match the rest (videos not yet reindexed) and keep the
+// title weight in the text score
+func synonymTextParts(synonym search.Synonym) []string {
+	parts := []string{synonym.Token}
+	seen := make(map[string]bool)
+	for _, variant := range synonym.Variants {
+		for _, word := range strings.Fields(variant) {
+			if !seen[word] && wordIndexable(word) {
+				seen[word] = true
+				parts = append(parts, word)
+			}
+		}
+	}
+	return parts
+}
+
+// filter is the exact-match filter of the query
+func (cq compiledQuery) filter() bson.M {
+	filter := bson.M{}
+	if cq.textSearch != "" {
+		filter["$text"] = bson.M{"$search": cq.textSearch}
+	}
+
+	conditions := append(append([]bson.M{}, cq.regexTerms...), cq.conditions...)
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+	return filter
+}
+
+func nodeCondition(node search.Node) bson.M {
+	switch n := node.(type) {
+	case search.Not:
+		return bson.M{"$nor": []bson.M{nodeCondition(n.Node)}}
+
+	case search.Or:
+		clauses := make([]bson.M, 0, len(n.Clauses))
+		for _, clause := range n.Clauses {
+			clauses = append(clauses, nodeCondition(clause))
+		}
+		return bson.M{"$or": clauses}
+
+	case search.Synonym:
+		variants := make([]bson.M, 0, len(n.Variants)+1)
+		if n.Token != "" {
+			variants = append(variants, bson.M{"search_terms": n.Token})
+		}
+		for _, variant := range n.Variants {
+			variants = append(variants, nodeCondition(search.Term{Field: n.Term.Field, Value: variant}))
+		}
+		return bson.M{"$or": variants}
+
+	case search.DateBound:
+		if n.Field == search.FieldBefore {
+			return bson.M{"published_at": bson.M{"$lt": n.Date}}
+		}
+		return bson.M{"published_at": bson.M{"$gte": n.Date}}
+
+	case search.Term:
+		switch n.Field {
+		case search.FieldTitle:
+			return bson.M{"normalized.title": prefixRegex(n.Value)}
+		case search.FieldChannel:
+			return bson.M{"$or": []bson.M{
+				{"channel_title": bson.M{"$regex": regexp.QuoteMeta(n.Value), "$options": "i"}},
+				{"channel_id": n.Value},
+			}}
+		case search.FieldQuery:
+			return bson.M{"search_query": bson.M{"$regex": "^" + regexp.QuoteMeta(n.Value) + "$", "$options": "i"}}
+		default:
+			return bson.M{"$or": []bson.M{
+				{"normalized.title": prefixRegex(n.Value)},
+				{"normalized.description": prefixRegex(n.Value)},
+			}}
+		}
+	}
+
+	return bson.M{}
+}
+
+// textIndexable reports whether the text index can match a term: at least
+// one whole word that is long enough, not a stop word and free of symbols
+// the index tokenizer splits on ("c++", "#shorts")
+func textIndexable(term search.Term) bool {
+	words := strings.Fields(search.Normalize(term.Value))
+	if !term.Phrase && len(words) != 1 {
+		return false
+	}
+
+	for _, word := range words {
+		if wordIndexable(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func wordIndexable(word string) bool {
+	if len([]rune(word)) < minTextTokenLength || search.IsStopWord(word) {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixRegex matches words (or phrases) starting with value in
+// normalized text, so case, accents and compatibility forms don't matter
+func prefixRegex(value string) bson.M {
+	words := strings.Fields(search.Normalize(value))
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	pattern := `(^|[^\p{L}\p{N}_])` + strings.Join(words, `\s+`)
+	return bson.M{"$regex": pattern, "$options": "i"}
+}
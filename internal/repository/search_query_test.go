@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"fampay-youtube-api/internal/search"
+)
+
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		query          string
+		wantText       string
+		wantRegexTerms int
+		wantConditions int
+	}{
+		// $text matches any bare word, so every word is also required by a regex
+		{query: "running shoes", wantText: "running shoes", wantRegexTerms: 2},
+		// $text requires quoted phrases itself
+		{query: `"world cup" final`, wantText: `"world cup" final`, wantRegexTerms: 1},
+		// Short words, stop words and symbols only use prefix regexes
+		{query: "c++ tutorial", wantText: "tutorial", wantRegexTerms: 2},
+		{query: "go", wantRegexTerms: 1},
+		{query: "the", wantRegexTerms: 1},
+		// $text can't be negated or nested, so exclusions and ORs are conditions
+		{query: "cricket -highlights", wantText: "cricket", wantRegexTerms: 1, wantConditions: 1},
+		{query: "cricket OR football", wantConditions: 1},
+		{query: "title:final channel:espn after:2026-01-01", wantConditions: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			parsed, err := search.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+			}
+
+			compiled := compileQuery(parsed)
+			if compiled.textSearch != tt.wantText {
+				t.Errorf("textSearch = %q, want %q", compiled.textSearch, tt.wantText)
+			}
+			if len(compiled.regexTerms) != tt.wantRegexTerms {
+				t.Errorf("got %d regex terms, want %d", len(compiled.regexTerms), tt.wantRegexTerms)
+			}
+			if len(compiled.conditions) != tt.wantConditions {
+				t.Errorf("got %d conditions, want %d", len(compiled.conditions), tt.wantConditions)
+			}
+
+			filter := compiled.filter()
+			if _, ok := filter["$text"]; ok != (tt.wantText != "") {
+				t.Errorf("filter has $text = %v, want %v", ok, tt.wantText != "")
+			}
+			and, _ := filter["$and"].([]bson.M)
+			if len(and) != tt.wantRegexTerms+tt.wantConditions {
+				t.Errorf("filter $and has %d conditions, want %d", len(and), tt.wantRegexTerms+tt.wantConditions)
+			}
+		})
+	}
+}
+
+func TestCompileQueryRequiresEveryWord(t *testing.T) {
+	parsed, err := search.ParseQuery("cricket world cup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := compileQuery(parsed).filter()
+	want := bson.M{
+		"$text": bson.M{"$search": "cricket world cup"},
+		"$and": []bson.M{
+			{"$or": []bson.M{{"normalized.title": prefixRegex("cricket")}, {"normalized.description": prefixRegex("cricket")}}},
+			{"$or": []bson.M{{"normalized.title": prefixRegex("world")}, {"normalized.description": prefixRegex("world")}}},
+			{"$or": []bson.M{{"normalized.title": prefixRegex("cup")}, {"normalized.description": prefixRegex("cup")}}},
+		},
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("filter() =\n  %v\nwant\n  %v", filter, want)
+	}
+}
+
+func TestCompileQuerySynonyms(t *testing.T) {
+	dictionary := search.NewDictionary([]search.SynonymGroup{
+		{Token: "syncricket", Terms: []string{"cricket", "kriket", "क्रिकेट"}},
+		{Token: "synworldcup", Terms: []string{"world cup", "wc"}},
+	})
+
+	tests := []struct {
+		query          string
+		wantText       string
+		wantRegexTerms int
+	}{
+		// The marker matches reindexed videos, the variants ones indexed before
+		{query: "kriket", wantText: "syncricket cricket kriket क्रिकेट", wantRegexTerms: 1},
+		{query: `"world cup" final`, wantText: "synworldcup world cup final", wantRegexTerms: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			parsed, err := search.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+			}
+			compiled := compileQuery(dictionary.Expand(parsed))
+			if compiled.textSearch != tt.wantText {
+				t.Errorf("textSearch = %q, want %q", compiled.textSearch, tt.wantText)
+			}
+			// Each synonym requires its marker or any variant
+			if len(compiled.regexTerms) != tt.wantRegexTerms {
+				t.Fatalf("got %d regex terms, want %d", len(compiled.regexTerms), tt.wantRegexTerms)
+			}
+			variants, _ := compiled.regexTerms[0]["$or"].([]bson.M)
+			if len(variants) == 0 || variants[0]["search_terms"] == nil {
+				t.Errorf("synonym condition = %v, want the marker first", compiled.regexTerms[0])
+			}
+		})
+	}
+}
+
+func TestPrefixRegex(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"tea", `(^|[^\p{L}\p{N}_])tea`},
+		{"C++", `(^|[^\p{L}\p{N}_])c\+\+`},
+		{"Café  Noir", `(^|[^\p{L}\p{N}_])cafe\s+noir`},
+	}
+
+	for _, tt := range tests {
+		if got := prefixRegex(tt.value)["$regex"]; got != tt.want {
+			t.Errorf("prefixRegex(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
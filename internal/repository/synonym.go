@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type SynonymRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewSynonymRepository(db *mongo.Database) *SynonymRepository {
+	return &SynonymRepository{
+		db:         db,
+		collection: db.Collection("synonym_groups"),
+	}
+}
+
+func (r *SynonymRepository) List() ([]models.SynonymGroup, error) {
+	return r.find(bson.M{})
+}
+
+// ListEnabled returns the groups applied to search
+func (r *SynonymRepository) ListEnabled() ([]models.SynonymGroup, error) {
+	return r.find(bson.M{"enabled": true})
+}
+
+func (r *SynonymRepository) find(filter bson.M) ([]models.SynonymGroup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find synonym groups: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := []models.SynonymGroup{}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode synonym groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (r *SynonymRepository) GetByID(id primitive.ObjectID) (*models.SynonymGroup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var group models.SynonymGroup
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&group)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Group not found
+		}
+		return nil, fmt.Errorf("failed to get synonym group: %w", err)
+	}
+
+	return &group, nil
+}
+
+func (r *SynonymRepository) Create(group *models.SynonymGroup) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, group)
+	if err != nil {
+		return fmt.Errorf("failed to create synonym group: %w", err)
+	}
+	group.ID = result.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
+// Update replaces a group, returning false when it does not exist
+func (r *SynonymRepository) Update(group *models.SynonymGroup) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"name":       group.Name,
+		"terms":      group.Terms,
+		"enabled":    group.Enabled,
+		"updated_at": group.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": group.ID}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to update synonym group: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes a group, returning false when it does not exist
+func (r *SynonymRepository) Delete(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete synonym group: %w", err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+type TranscriptRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewTranscriptRepository(db *mongo.Database) *TranscriptRepository {
+	return &TranscriptRepository{
+		db:         db,
+		collection: db.Collection("transcript_segments"),
+	}
+}
+
+// ReplaceForVideo stores a video's transcript, replacing any previous one
+func (r *TranscriptRepository) ReplaceForVideo(video *models.Video, language string, segments []models.TranscriptSegment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"video_id": video.VideoID}); err != nil {
+		return fmt.Errorf("failed to clear transcript: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		segment.VideoID = video.VideoID
+		segment.Language = language
+		segment.ChannelID = video.ChannelID
+		segment.SearchQuery = video.SearchQuery
+		segment.PublishedAt = video.PublishedAt
+		segment.CreatedAt = now
+		documents = append(documents, segment)
+	}
+
+	if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to store transcript: %w", err)
+	}
+
+	return nil
+}
+
+// ReindexNormalizedText rewrites normalized_text of stored segments whose
+// text normalizes differently, e.g. after changing search.Normalize
+func (r *TranscriptRepository) ReindexNormalizedText() (int64, error) {
+	ctx := context.Background()
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"text": 1, "normalized_text": 1}).
+		SetBatchSize(reindexBatchSize)
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find transcript segments to reindex: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	writes := make([]mongo.WriteModel, 0, reindexBatchSize)
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		result, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("failed to store normalized transcript text: %w", err)
+		}
+		updated += result.ModifiedCount
+		writes = writes[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		var segment models.TranscriptSegment
+		if err := cursor.Decode(&segment); err != nil {
+			return updated, fmt.Errorf("failed to decode transcript segment: %w", err)
+		}
+
+		normalized := search.Normalize(segment.Text)
+		if normalized == segment.NormalizedText {
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"normalized_text": normalized}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": segment.ID}).SetUpdate(update))
+		if len(writes) == reindexBatchSize {
+			if err := flush(); err != nil {
+				return updated, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return updated, fmt.Errorf("failed to iterate transcript segments: %w", err)
+	}
+
+	return updated, flush()
+}
+
+// BackfillVideoFields copies the filterable video fields onto segments
+// stored before they were kept on each segment
+func (r *TranscriptRepository) BackfillVideoFields() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	missing := bson.M{"channel_id": bson.M{"$exists": false}}
+	videoIDs, err := r.collection.Distinct(ctx, "video_id", missing)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find transcripts to backfill: %w", err)
+	}
+
+	var updated int64
+	for _, videoID := range videoIDs {
+		var video models.Video
+		err := r.db.Collection("videos").FindOne(ctx, bson.M{"video_id": videoID}).Decode(&video)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return updated, fmt.Errorf("failed to get video %v: %w", videoID, err)
+		}
+
+		update := bson.M{"$set": bson.M{
+			"channel_id":   video.ChannelID,
+			"search_query": video.SearchQuery,
+			"published_at": video.PublishedAt,
+		}}
+		result, err := r.collection.UpdateMany(ctx, bson.M{"video_id": videoID, "channel_id": missing["channel_id"]}, update)
+		if err != nil {
+			return updated, fmt.Errorf("failed to backfill transcript of video %v: %w", videoID, err)
+		}
+		updated += result.ModifiedCount
+	}
+
+	return updated, nil
+}
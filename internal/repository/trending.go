@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type TrendingRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewTrendingRepository(db *mongo.Database) *TrendingRepository {
+	return &TrendingRepository{
+		db:         db,
+		collection: db.Collection("trending_snapshots"),
+	}
+}
+
+func (r *TrendingRepository) Create(snapshot *models.TrendingSnapshot) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.InsertOne(ctx, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to store trending snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest returns the most recent snapshot of a chart, or nil if it was never fetched
+func (r *TrendingRepository) GetLatest(region, categoryID string) (*models.TrendingSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.FindOne()
+	findOptions.SetSort(bson.D{{Key: "fetched_at", Value: -1}})
+
+	var snapshot models.TrendingSnapshot
+	err := r.collection.FindOne(ctx, bson.M{"region": region, "category_id": categoryID}, findOptions).Decode(&snapshot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Chart not fetched yet
+		}
+		return nil, fmt.Errorf("failed to get trending snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
 )
 
 type VideoRepository struct {
@@ -25,129 +27,183 @@ func NewVideoRepository(db *mongo.Database) *VideoRepository {
 	}
 }
 
-// Enhanced search with better partial matching
-func (r *VideoRepository) Search(query string, page, pageSize int, sortBy string) ([]models.Video, int64, error) {
+// Search finds videos matching a parsed search query. sort=relevance ranks
+// by text score (title matches weigh more than description matches) and
+// only supports page numbers, since text scores can't be used in keyset
+// conditions.
+func (r *VideoRepository) Search(query *search.Query, pagination Pagination, sortBy string, videoFilter VideoFilter) ([]models.Video, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Build search filter for partial matching
-	filter := r.buildSearchFilter(query)
+	// Build search filter ($text plus prefix regex fallback)
+	compiled := compileQuery(query)
+	filter := videoFilter.apply(compiled.filter())
 
-	// Count total matching documents
-	total, err := r.collection.CountDocuments(ctx, filter)
+	findOptions := options.Find()
+	if compiled.textSearch != "" {
+		textScore := bson.M{"$meta": "textScore"}
+		findOptions.SetProjection(bson.M{"score": textScore})
+
+		if sortBy == "relevance" {
+			if pagination.Cursor != nil {
+				return nil, nil, ErrInvalidCursor
+			}
+			return r.searchByRelevance(ctx, filter, pagination, findOptions)
+		}
+	}
+
+	// Relevance without any indexable word falls back to latest
+	videos, info, err := findPage[models.Video](ctx, r.collection, filter, r.buildSortOptions(sortBy), sortBy, pagination, findOptions)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+		return nil, nil, fmt.Errorf("failed to search videos: %w", err)
 	}
 
-	// Build sort options
-	sortOptions := r.buildSortOptions(sortBy)
+	return videos, info, nil
+}
 
-	// Calculate skip
-	skip := (page - 1) * pageSize
+func (r *VideoRepository) searchByRelevance(ctx context.Context, filter bson.M, pagination Pagination, findOptions *options.FindOptions) ([]models.Video, *PageInfo, error) {
+	// Count total matching documents
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count search results: %w", err)
+	}
 
-	// Find options
-	findOptions := options.Find()
-	findOptions.SetSort(sortOptions)
-	findOptions.SetLimit(int64(pageSize))
-	findOptions.SetSkip(int64(skip))
+	findOptions.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetLimit(int64(pagination.PageSize))
+	findOptions.SetSkip(int64((pagination.Page - 1) * pagination.PageSize))
 
-	// Find videos
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to search videos: %w", err)
+		return nil, nil, fmt.Errorf("failed to search videos: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var videos []models.Video
+	videos := []models.Video{}
 	if err = cursor.All(ctx, &videos); err != nil {
-		return nil, 0, fmt.Errorf("failed to decode search results: %w", err)
+		return nil, nil, fmt.Errorf("failed to decode search results: %w", err)
 	}
 
-	return videos, total, nil
+	return videos, &PageInfo{Total: &total}, nil
 }
 
-func (r *VideoRepository) buildSearchFilter(query string) bson.M {
-	if strings.TrimSpace(query) == "" {
-		return bson.M{}
-	}
+// Matching transcript segments returned per video
+const transcriptMatchesPerVideo = 5
 
-	// Split query into words for better partial matching
-	words := strings.Fields(strings.ToLower(query))
+// SearchTranscripts finds videos whose captions contain every query word
+// within a single segment, returning the matching segments with timestamps
+func (r *VideoRepository) SearchTranscripts(query string, pagination Pagination, sortBy string, videoFilter VideoFilter) ([]models.TranscriptSearchResult, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
 
-	var orConditions []bson.M
+	match := transcriptSearchFilter(query, videoFilter)
+	if match == nil {
+		var total int64
+		return []models.TranscriptSearchResult{}, &PageInfo{Total: &total}, nil
+	}
 
-	// For each word, create regex patterns for title and description
-	for _, word := range words {
-		// Escape special regex characters
-		escapedWord := strings.ReplaceAll(word, `\`, `\\`)
-		escapedWord = strings.ReplaceAll(escapedWord, `.`, `\.`)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "video_id", Value: 1}, {Key: "start", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$video_id",
+			"matches":     bson.M{"$push": bson.M{"start": "$start", "duration": "$duration", "text": "$text"}},
+			"match_count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.collection.Name(),
+			"localField":   "_id",
+			"foreignField": "video_id",
+			"as":           "video",
+		}}},
+		{{Key: "$unwind", Value: "$video"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{"$mergeObjects": bson.A{
+			"$video",
+			bson.M{
+				"transcript_matches":     bson.M{"$slice": bson.A{"$matches", transcriptMatchesPerVideo}},
+				"transcript_match_count": "$match_count",
+			},
+		}}}}},
+		// Enrichment fields can change after ingestion, so they're checked on the video
+		{{Key: "$match", Value: videoFilter.apply(bson.M{})}},
+	}
 
-		wordConditions := []bson.M{
-			{"title": bson.M{"$regex": escapedWord, "$options": "i"}},
-			{"description": bson.M{"$regex": escapedWord, "$options": "i"}},
+	results, info, err := aggregatePage[models.TranscriptSearchResult](ctx, r.db.Collection("transcript_segments"), pipeline, r.buildSortOptions(sortBy), sortBy, pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search transcripts: %w", err)
+	}
+
+	// Deep links start playback at the matching segment
+	for i := range results {
+		for j := range results[i].Matches {
+			match := &results[i].Matches[j]
+			match.URL = fmt.Sprintf("https://www.youtube.com/watch?v=%s&t=%ds", results[i].VideoID, int(match.Start))
 		}
-		orConditions = append(orConditions, bson.M{"$or": wordConditions})
 	}
 
-	// All words must match (AND logic), but can match in either title OR description
-	if len(orConditions) == 1 {
-		return orConditions[0]
+	return results, info, nil
+}
+
+// transcriptSearchFilter matches segments containing every query word at
+// the start of a word, or returns nil when the query has no words. The
+// text index narrows the segments first; it matches any of the words, so
+// the regexes require all of them. Segments carry the video fields that
+// don't change after ingestion, so those filters apply before grouping.
+func transcriptSearchFilter(query string, videoFilter VideoFilter) bson.M {
+	// Segments store their text in the same normalized form
+	words := strings.Fields(search.Normalize(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var textWords []string
+	wordConditions := make([]bson.M, 0, len(words))
+	for _, word := range words {
+		if wordIndexable(word) {
+			textWords = append(textWords, word)
+		}
+		wordConditions = append(wordConditions, bson.M{"normalized_text": prefixRegex(word)})
 	}
 
-	return bson.M{"$and": orConditions}
+	match := videoFilter.segmentFilter().apply(bson.M{"$and": wordConditions})
+	if len(textWords) > 0 {
+		match["$text"] = bson.M{"$search": strings.Join(textWords, " ")}
+	}
+	return match
 }
 
 func (r *VideoRepository) buildSortOptions(sortBy string) bson.D {
 	switch sortBy {
 	case "oldest":
-		return bson.D{{"published_at", 1}}
+		return bson.D{{Key: "published_at", Value: 1}, {Key: "_id", Value: 1}}
 	case "title":
-		return bson.D{{"title", 1}}
+		return bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}
 	case "channel":
-		return bson.D{{"channel_title", 1}, {"published_at", -1}}
+		return bson.D{{Key: "channel_title", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
+	case "shortest":
+		return bson.D{{Key: "duration_seconds", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
+	case "longest":
+		return bson.D{{Key: "duration_seconds", Value: -1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
 	case "latest":
-		return bson.D{{"published_at", -1}}
+		return bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
 	default:
-		return bson.D{{"published_at", -1}}
+		return bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
 	}
 }
 
-// Enhanced get paginated with sorting
-func (r *VideoRepository) GetPaginated(page, pageSize int, sortBy string) ([]models.Video, int64, error) {
+// GetPaginated lists videos by page number or cursor. Every sort ends with
+// _id so the order is stable for keyset pagination.
+func (r *VideoRepository) GetPaginated(pagination Pagination, sortBy string, videoFilter VideoFilter) ([]models.Video, *PageInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Count total documents
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count videos: %w", err)
-	}
-
-	// Build sort options
-	sortOptions := r.buildSortOptions(sortBy)
-
-	// Calculate skip
-	skip := (page - 1) * pageSize
-
-	// Find options
-	findOptions := options.Find()
-	findOptions.SetSort(sortOptions)
-	findOptions.SetLimit(int64(pageSize))
-	findOptions.SetSkip(int64(skip))
+	filter := videoFilter.apply(bson.M{})
 
-	// Find videos
-	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	videos, info, err := findPage[models.Video](ctx, r.collection, filter, r.buildSortOptions(sortBy), sortBy, pagination, nil)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to find videos: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	var videos []models.Video
-	if err = cursor.All(ctx, &videos); err != nil {
-		return nil, 0, fmt.Errorf("failed to decode videos: %w", err)
+		return nil, nil, fmt.Errorf("failed to find videos: %w", err)
 	}
 
-	return videos, total, nil
+	return videos, info, nil
 }
 
 func (r *VideoRepository) Create(video *models.Video) error {
@@ -156,15 +212,35 @@ func (r *VideoRepository) Create(video *models.Video) error {
 
 	video.CreatedAt = time.Now()
 	video.UpdatedAt = time.Now()
+	applySearchFields(video)
 
-	_, err := r.collection.InsertOne(ctx, video)
+	result, err := r.collection.InsertOne(ctx, video)
 	if err != nil {
 		return fmt.Errorf("failed to create video: %w", err)
 	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		video.ID = id
+	}
 
 	return nil
 }
 
+// BackfillEnrichedFlag marks videos stored before the enriched flag
+// existed. Only enrichment sets a category, so videos with one were
+// enriched; the rest stay hidden from safety=strict.
+func (r *VideoRepository) BackfillEnrichedFlag() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	filter := bson.M{"enriched": bson.M{"$exists": false}, "category_id": bson.M{"$nin": bson.A{"", nil}}}
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"enriched": true}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill enriched flag: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
 func (r *VideoRepository) GetByVideoID(videoID string) (*models.Video, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -186,7 +262,7 @@ func (r *VideoRepository) GetLatest() (*models.Video, error) {
 	defer cancel()
 
 	findOptions := options.FindOne()
-	findOptions.SetSort(bson.D{{"published_at", -1}})
+	findOptions.SetSort(bson.D{{Key: "published_at", Value: -1}})
 
 	var video models.Video
 	err := r.collection.FindOne(ctx, bson.M{}, findOptions).Decode(&video)
@@ -199,3 +275,76 @@ func (r *VideoRepository) GetLatest() (*models.Video, error) {
 
 	return &video, nil
 }
+
+// GetLatestForQuery returns the most recently published video found by a search query
+func (r *VideoRepository) GetLatestForQuery(query string) (*models.Video, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.FindOne()
+	findOptions.SetSort(bson.D{{Key: "published_at", Value: -1}})
+
+	var video models.Video
+	err := r.collection.FindOne(ctx, bson.M{"search_query": query}, findOptions).Decode(&video)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // No videos found for this query
+		}
+		return nil, fmt.Errorf("failed to get latest video for query: %w", err)
+	}
+
+	return &video, nil
+}
+
+// GetStoredAfter returns up to limit videos stored after the one with
+// afterID, oldest first. ObjectIDs start with their creation time, so
+// this replays what a live feed client missed while disconnected.
+func (r *VideoRepository) GetStoredAfter(afterID primitive.ObjectID, videoFilter VideoFilter, limit int) ([]models.Video, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := videoFilter.apply(bson.M{"_id": bson.M{"$gt": afterID}})
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find videos stored after %s: %w", afterID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	videos := []models.Video{}
+	if err = cursor.All(ctx, &videos); err != nil {
+		return nil, fmt.Errorf("failed to decode videos: %w", err)
+	}
+
+	return videos, nil
+}
+
+// ForEach streams every stored video to fn, stopping at the first error
+func (r *VideoRepository) ForEach(fn func(video *models.Video) error) error {
+	ctx := context.Background()
+
+	findOptions := options.Find().SetBatchSize(reindexBatchSize)
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return fmt.Errorf("failed to find videos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var video models.Video
+		if err := cursor.Decode(&video); err != nil {
+			return fmt.Errorf("failed to decode video: %w", err)
+		}
+		if err := fn(&video); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read videos: %w", err)
+	}
+	return nil
+}
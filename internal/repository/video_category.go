@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetCategoryNames resolves category names on stored videos whose name is
+// missing or out of date. Returns the number of videos updated.
+func (r *VideoRepository) SetCategoryNames(names map[string]string) (int64, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var updated int64
+	for categoryID, name := range names {
+		result, err := r.collection.UpdateMany(ctx,
+			bson.M{"category_id": categoryID, "category_name": bson.M{"$ne": name}},
+			bson.M{"$set": bson.M{"category_name": name, "updated_at": time.Now()}},
+		)
+		if err != nil {
+			return updated, fmt.Errorf("failed to set category names: %w", err)
+		}
+		updated += result.ModifiedCount
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Buckets returned per facet (published_day returns the latest days)
+const facetBucketLimit = 20
+
+// facetStages returns the $facet sub-pipeline counting results per value
+func facetStages(name string) bson.A {
+	var group bson.M
+	sort := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	stages := bson.A{}
+
+	switch name {
+	case models.FacetChannel:
+		group = bson.M{"_id": "$channel_id", "label": bson.M{"$max": "$channel_title"}}
+	case models.FacetSearchQuery:
+		group = bson.M{"_id": "$search_query"}
+	case models.FacetPublishedDay:
+		group = bson.M{"_id": bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$published_at"}}}
+		sort = bson.D{{Key: "_id", Value: -1}}
+	case models.FacetCategory:
+		stages = append(stages, bson.M{"$match": bson.M{"category_id": bson.M{"$nin": bson.A{"", nil}}}})
+		group = bson.M{"_id": "$category_id", "label": bson.M{"$max": "$category_name"}}
+	}
+
+	group["count"] = bson.M{"$sum": 1}
+	return append(stages,
+		bson.M{"$group": group},
+		bson.M{"$sort": sort},
+		bson.M{"$limit": facetBucketLimit},
+	)
+}
+
+// SearchFacets counts the results of a search per value of each requested
+// facet in a single $facet aggregation. The category facet ignores the
+// category filter so clients can offer the other categories.
+func (r *VideoRepository) SearchFacets(query *search.Query, videoFilter VideoFilter, fuzzy bool, names []string) (map[string][]models.FacetBucket, error) {
+	facets := make(map[string][]models.FacetBucket, len(names))
+	if len(names) == 0 {
+		return facets, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	categoryIDs := videoFilter.CategoryIDs
+	videoFilter.CategoryIDs = nil
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: videoFilter.apply(compileQuery(query).filter())}}}
+	if fuzzy {
+		pipeline = fuzzyStages(query, videoFilter)
+	}
+
+	facetPipelines := bson.M{}
+	for _, name := range names {
+		stages := facetStages(name)
+		if len(categoryIDs) > 0 && name != models.FacetCategory {
+			stages = append(bson.A{bson.M{"$match": bson.M{"category_id": bson.M{"$in": categoryIDs}}}}, stages...)
+		}
+		facetPipelines[name] = stages
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: facetPipelines}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []map[string][]models.FacetBucket
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode search facets: %w", err)
+	}
+
+	for _, name := range names {
+		facets[name] = []models.FacetBucket{}
+		if len(results) > 0 && results[0][name] != nil {
+			facets[name] = results[0][name]
+		}
+	}
+
+	return facets, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Safety levels accepted by the listing and search endpoints
+const (
+	SafetyOff    = "off"
+	SafetyStrict = "strict"
+)
+
+// Enrichment fields that can be required (has=) or required missing (missing=)
+const (
+	FieldDuration      = "duration"
+	FieldCategory      = "category"
+	FieldFormat        = "format"
+	FieldContentRating = "content_rating"
+	FieldBroadcast     = "broadcast"
+)
+
+// Presence conditions per enrichment field
+var enrichmentFieldConditions = map[string]struct{ present, missing bson.M }{
+	FieldDuration:      {bson.M{"duration_seconds": bson.M{"$gt": 0}}, bson.M{"duration_seconds": bson.M{"$in": bson.A{0, nil}}}},
+	FieldCategory:      {bson.M{"category_id": bson.M{"$nin": bson.A{"", nil}}}, bson.M{"category_id": bson.M{"$in": bson.A{"", nil}}}},
+	FieldFormat:        {bson.M{"format": bson.M{"$nin": bson.A{"", nil}}}, bson.M{"format": bson.M{"$in": bson.A{"", nil}}}},
+	FieldContentRating: {bson.M{"content_rating": bson.M{"$nin": bson.A{"", nil}}}, bson.M{"content_rating": bson.M{"$in": bson.A{"", nil}}}},
+	FieldBroadcast:     {bson.M{"broadcast": bson.M{"$ne": nil}}, bson.M{"broadcast": nil}},
+}
+
+// ValidEnrichmentField reports whether name can be used with has= and missing=
+func ValidEnrichmentField(name string) bool {
+	_, ok := enrichmentFieldConditions[name]
+	return ok
+}
+
+// VideoFilter narrows the stored videos returned by listing and search
+type VideoFilter struct {
+	Safety        string   // "strict" keeps only enriched videos that aren't age-restricted
+	Format        string   // short, standard, live or premiere
+	ChannelIDs    []string // Videos from any of these channels
+	CategoryIDs   []string // Videos in any of these categories
+	SearchQueries []string // Videos found by any of these background search queries
+	Languages     []string // Videos in any of these languages (ISO 639-1, or "und")
+
+	PublishedAfter  *time.Time // Inclusive
+	PublishedBefore *time.Time // Exclusive
+	IngestedAfter   *time.Time // Inclusive, on created_at
+	IngestedBefore  *time.Time // Exclusive, on created_at
+
+	Has     []string // Enrichment fields that must be present
+	Missing []string // Enrichment fields that must be missing
+}
+
+func (f VideoFilter) conditions() []bson.M {
+	var conditions []bson.M
+
+	// Fails closed: a video whose enrichment failed carries no rating, so
+	// it can't be shown as safe
+	if f.Safety == SafetyStrict {
+		conditions = append(conditions,
+			bson.M{"enriched": true},
+			bson.M{"age_restricted": bson.M{"$ne": true}},
+			bson.M{"content_rating": bson.M{"$ne": "ytAgeRestricted"}},
+		)
+	}
+
+	if f.Format != "" {
+		conditions = append(conditions, bson.M{"format": f.Format})
+	}
+
+	if len(f.ChannelIDs) > 0 {
+		conditions = append(conditions, bson.M{"channel_id": bson.M{"$in": f.ChannelIDs}})
+	}
+
+	if len(f.CategoryIDs) > 0 {
+		conditions = append(conditions, bson.M{"category_id": bson.M{"$in": f.CategoryIDs}})
+	}
+
+	if len(f.SearchQueries) > 0 {
+		conditions = append(conditions, bson.M{"search_query": bson.M{"$in": f.SearchQueries}})
+	}
+
+	if len(f.Languages) > 0 {
+		conditions = append(conditions, bson.M{"lang": bson.M{"$in": f.Languages}})
+	}
+
+	if published := timeRange(f.PublishedAfter, f.PublishedBefore); published != nil {
+		conditions = append(conditions, bson.M{"published_at": published})
+	}
+	if ingested := timeRange(f.IngestedAfter, f.IngestedBefore); ingested != nil {
+		conditions = append(conditions, bson.M{"created_at": ingested})
+	}
+
+	for _, field := range f.Has {
+		if condition, ok := enrichmentFieldConditions[field]; ok {
+			conditions = append(conditions, condition.present)
+		}
+	}
+	for _, field := range f.Missing {
+		if condition, ok := enrichmentFieldConditions[field]; ok {
+			conditions = append(conditions, condition.missing)
+		}
+	}
+
+	return conditions
+}
+
+func timeRange(after, before *time.Time) bson.M {
+	if after == nil && before == nil {
+		return nil
+	}
+
+	condition := bson.M{}
+	if after != nil {
+		condition["$gte"] = *after
+	}
+	if before != nil {
+		condition["$lt"] = *before
+	}
+	return condition
+}
+
+// segmentFilter keeps the conditions on video fields that are copied onto
+// transcript segments (see models.TranscriptSegment)
+func (f VideoFilter) segmentFilter() VideoFilter {
+	return VideoFilter{
+		ChannelIDs:      f.ChannelIDs,
+		SearchQueries:   f.SearchQueries,
+		PublishedAfter:  f.PublishedAfter,
+		PublishedBefore: f.PublishedBefore,
+	}
+}
+
+// apply combines the filter with a base query using AND logic
+func (f VideoFilter) apply(base bson.M) bson.M {
+	conditions := f.conditions()
+	if len(conditions) == 0 {
+		return base
+	}
+
+	if len(base) > 0 {
+		conditions = append([]bson.M{base}, conditions...)
+	}
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return bson.M{"$and": conditions}
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Parameters read by ParseVideoFilter
+var videoFilterParams = map[string]bool{
+	"safety": true, "format": true, "category": true, "channel_id": true,
+	"search_query": true, "lang": true, "published_after": true,
+	"published_before": true, "published_within": true, "ingested_after": true,
+	"ingested_before": true, "has": true, "missing": true,
+}
+
+// IsVideoFilterParam reports whether ParseVideoFilter reads a parameter
+func IsVideoFilterParam(param string) bool {
+	return videoFilterParams[param]
+}
+
+// ParseVideoFilter reads listing and search filter parameters (safety,
+// format, category, channel_id, lang, dates, has/missing). Saved searches
+// store the same parameters.
+func ParseVideoFilter(values url.Values) (VideoFilter, error) {
+	var filter VideoFilter
+
+	safety := values.Get("safety")
+	if safety == "" {
+		safety = SafetyOff
+	}
+	switch safety {
+	case SafetyOff, SafetyStrict:
+		filter.Safety = safety
+	default:
+		return filter, fmt.Errorf("invalid safety %q (expected off or strict)", safety)
+	}
+
+	if format := values.Get("format"); format != "" {
+		if !models.ValidFormat(format) {
+			return filter, fmt.Errorf("invalid format %q (expected short, standard, live or premiere)", format)
+		}
+		filter.Format = format
+	}
+
+	// Comma-separated category IDs, e.g. category=17,20
+	if category := values.Get("category"); category != "" {
+		for _, id := range strings.Split(category, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			if _, err := strconv.Atoi(id); err != nil {
+				return filter, fmt.Errorf("invalid category %q (expected numeric category IDs, see /api/categories)", id)
+			}
+			filter.CategoryIDs = append(filter.CategoryIDs, id)
+		}
+	}
+
+	// Comma-separated channel IDs (the channel page sets its own channel)
+	channelIDs, err := parseList(values, "channel_id", maxFilterValues)
+	if err != nil {
+		return filter, err
+	}
+	filter.ChannelIDs = channelIDs
+
+	// Originating background search queries
+	if filter.SearchQueries, err = parseList(values, "search_query", maxFilterValues); err != nil {
+		return filter, err
+	}
+
+	// Languages, e.g. lang=hi,en (region subtags are ignored: en-GB is en)
+	languages, err := parseList(values, "lang", maxFilterValues)
+	if err != nil {
+		return filter, err
+	}
+	for _, tag := range languages {
+		code, ok := search.LanguageCode(tag)
+		if tag == search.LanguageUndetermined {
+			code, ok = tag, true
+		}
+		if !ok {
+			return filter, fmt.Errorf("invalid lang %q (expected language codes such as en, hi or ta)", tag)
+		}
+		filter.Languages = append(filter.Languages, code)
+	}
+
+	// Publish date range; published_within=6h is shorthand for published_after=now-6h
+	if filter.PublishedAfter, err = parseTimeParam(values, "published_after"); err != nil {
+		return filter, err
+	}
+	if filter.PublishedBefore, err = parseTimeParam(values, "published_before"); err != nil {
+		return filter, err
+	}
+	if within := values.Get("published_within"); within != "" {
+		duration, err := time.ParseDuration(within)
+		if err != nil || duration <= 0 {
+			return filter, fmt.Errorf("invalid published_within %q (expected a duration such as 30m, 6h or 168h)", within)
+		}
+		if filter.PublishedAfter != nil {
+			return filter, fmt.Errorf("published_within cannot be combined with published_after")
+		}
+		after := time.Now().Add(-duration)
+		filter.PublishedAfter = &after
+	}
+	if err := validateTimeRange("published", filter.PublishedAfter, filter.PublishedBefore); err != nil {
+		return filter, err
+	}
+
+	// Ingestion time (when the fetcher stored the video)
+	if filter.IngestedAfter, err = parseTimeParam(values, "ingested_after"); err != nil {
+		return filter, err
+	}
+	if filter.IngestedBefore, err = parseTimeParam(values, "ingested_before"); err != nil {
+		return filter, err
+	}
+	if err := validateTimeRange("ingested", filter.IngestedAfter, filter.IngestedBefore); err != nil {
+		return filter, err
+	}
+
+	// Presence of enrichment fields, e.g. has=category,duration or missing=category
+	for _, param := range []string{"has", "missing"} {
+		fields, err := parseList(values, param, maxFilterValues)
+		if err != nil {
+			return filter, err
+		}
+		for _, field := range fields {
+			if !ValidEnrichmentField(field) {
+				return filter, fmt.Errorf("invalid %s field %q (expected duration, category, format, content_rating or broadcast)", param, field)
+			}
+		}
+		if param == "has" {
+			filter.Has = fields
+		} else {
+			filter.Missing = fields
+		}
+	}
+
+	return filter, nil
+}
+
+// Upper bound of comma-separated values in a list filter
+const maxFilterValues = 50
+
+// ParseListParam reads a comma-separated parameter with the listing
+// filters' value limit
+func ParseListParam(values url.Values, param string) ([]string, error) {
+	return parseList(values, param, maxFilterValues)
+}
+
+// parseList reads a comma-separated query parameter, dropping blanks
+func parseList(values url.Values, param string, limit int) ([]string, error) {
+	var list []string
+	for _, value := range strings.Split(values.Get(param), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	if len(list) > limit {
+		return nil, fmt.Errorf("too many %s values (max %d)", param, limit)
+	}
+	return list, nil
+}
+
+// parseTimeParam accepts RFC 3339 timestamps or YYYY-MM-DD dates (UTC midnight)
+func parseTimeParam(values url.Values, param string) (*time.Time, error) {
+	value := values.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s %q (expected RFC 3339 such as 2026-01-02T15:04:05Z or YYYY-MM-DD)", param, value)
+}
+
+func validateTimeRange(name string, after, before *time.Time) error {
+	if after != nil && before != nil && !after.Before(*before) {
+		return fmt.Errorf("%s_after must be earlier than %s_before", name, name)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVideoFilter(t *testing.T) {
+	date := func(value string) *time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &parsed
+	}
+	manyChannels := strings.TrimSuffix(strings.Repeat("UCa,", maxFilterValues+1), ",")
+
+	tests := []struct {
+		name    string
+		query   string
+		want    VideoFilter
+		wantErr string
+	}{
+		{name: "defaults", query: "", want: VideoFilter{Safety: SafetyOff}},
+		{
+			name:  "dates",
+			query: "published_after=2026-01-02&published_before=2026-01-03T10:30:00%2B05:30",
+			want:  VideoFilter{Safety: SafetyOff, PublishedAfter: date("2026-01-02T00:00:00Z"), PublishedBefore: date("2026-01-03T10:30:00+05:30")},
+		},
+		{name: "invalid date", query: "published_after=02/01/2026", wantErr: "invalid published_after"},
+		{name: "empty range", query: "published_after=2026-01-02&published_before=2026-01-02", wantErr: "published_after must be earlier than published_before"},
+		{name: "empty ingestion range", query: "ingested_after=2026-01-03&ingested_before=2026-01-02", wantErr: "ingested_after must be earlier than ingested_before"},
+		{name: "invalid published_within", query: "published_within=-6h", wantErr: "invalid published_within"},
+		{name: "published_within and published_after", query: "published_within=6h&published_after=2026-01-02", wantErr: "cannot be combined"},
+		{
+			name:  "lists",
+			query: "channel_id=UCa,+UCb+,,&search_query=cricket&category=17,+20&lang=hi,en-GB,und",
+			want: VideoFilter{
+				Safety:        SafetyOff,
+				ChannelIDs:    []string{"UCa", "UCb"},
+				SearchQueries: []string{"cricket"},
+				CategoryIDs:   []string{"17", "20"},
+				Languages:     []string{"hi", "en", "und"},
+			},
+		},
+		{name: "too many values", query: "channel_id=" + manyChannels, wantErr: "too many channel_id values"},
+		{name: "invalid category", query: "category=sports", wantErr: "invalid category"},
+		{name: "invalid lang", query: "lang=klingon", wantErr: "invalid lang"},
+		{
+			name:  "has and missing",
+			query: "has=duration,category&missing=broadcast",
+			want:  VideoFilter{Safety: SafetyOff, Has: []string{FieldDuration, FieldCategory}, Missing: []string{FieldBroadcast}},
+		},
+		{name: "invalid has field", query: "has=thumbnails", wantErr: `invalid has field "thumbnails"`},
+		{name: "invalid missing field", query: "missing=title", wantErr: `invalid missing field "title"`},
+		{name: "invalid safety", query: "safety=moderate", wantErr: "invalid safety"},
+		{name: "invalid format", query: "format=vertical", wantErr: "invalid format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ParseVideoFilter(values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseVideoFilter(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVideoFilter(%q) error = %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVideoFilter(%q) =\n  %+v\nwant\n  %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	// published_within is relative to now
+	got, err := ParseVideoFilter(url.Values{"published_within": {"6h"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublishedAfter == nil || time.Since(*got.PublishedAfter)-6*time.Hour > time.Minute {
+		t.Errorf("published_within=6h gave published_after %v, want about 6 hours ago", got.PublishedAfter)
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestVideoFilterConditions(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter VideoFilter
+		want   []bson.M
+	}{
+		{name: "empty", filter: VideoFilter{}},
+		{name: "safety off", filter: VideoFilter{Safety: SafetyOff}},
+		{
+			// Unenriched videos have no rating to check, so strict leaves them out
+			name:   "strict safety",
+			filter: VideoFilter{Safety: SafetyStrict},
+			want: []bson.M{
+				{"enriched": true},
+				{"age_restricted": bson.M{"$ne": true}},
+				{"content_rating": bson.M{"$ne": "ytAgeRestricted"}},
+			},
+		},
+		{
+			name:   "lists",
+			filter: VideoFilter{Format: "short", ChannelIDs: []string{"UCa", "UCb"}, CategoryIDs: []string{"17"}, SearchQueries: []string{"cricket"}, Languages: []string{"hi"}},
+			want: []bson.M{
+				{"format": "short"},
+				{"channel_id": bson.M{"$in": []string{"UCa", "UCb"}}},
+				{"category_id": bson.M{"$in": []string{"17"}}},
+				{"search_query": bson.M{"$in": []string{"cricket"}}},
+				{"lang": bson.M{"$in": []string{"hi"}}},
+			},
+		},
+		{
+			name:   "date ranges",
+			filter: VideoFilter{PublishedAfter: &after, PublishedBefore: &before, IngestedBefore: &before},
+			want: []bson.M{
+				{"published_at": bson.M{"$gte": after, "$lt": before}},
+				{"created_at": bson.M{"$lt": before}},
+			},
+		},
+		{
+			name:   "has and missing",
+			filter: VideoFilter{Has: []string{FieldDuration}, Missing: []string{FieldBroadcast}},
+			want: []bson.M{
+				{"duration_seconds": bson.M{"$gt": 0}},
+				{"broadcast": nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.conditions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("conditions() =\n  %v\nwant\n  %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoFilterApply(t *testing.T) {
+	base := bson.M{"$text": bson.M{"$search": "cricket"}}
+	format := VideoFilter{Format: "live"}
+
+	tests := []struct {
+		name   string
+		filter VideoFilter
+		base   bson.M
+		want   bson.M
+	}{
+		{name: "no conditions", filter: VideoFilter{}, base: base, want: base},
+		{name: "single condition", filter: format, base: bson.M{}, want: bson.M{"format": "live"}},
+		{name: "combined", filter: format, base: base, want: bson.M{"$and": []bson.M{base, {"format": "live"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.apply(tt.base); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Share of the query's trigrams a title must contain to be a fuzzy match
+// ("criket" shares 5 of 7 trigrams with "cricket")
+const fuzzyMinSimilarity = 0.5
+
+// fuzzyStages matches titles sharing enough trigrams with the query's words
+// and sets score to the share of query trigrams found in the title. Field
+// qualifiers, dates and exclusions still apply exactly.
+func fuzzyStages(query *search.Query, videoFilter VideoFilter) mongo.Pipeline {
+	compiled := compileQuery(query)
+	grams := search.Trigrams(compiled.fuzzyText)
+	if len(grams) == 0 {
+		// Nothing to compare, e.g. only qualifiers or punctuation
+		return mongo.Pipeline{{{Key: "$match", Value: bson.M{"_id": bson.M{"$exists": false}}}}}
+	}
+
+	match := bson.M{"title_ngrams": bson.M{"$in": grams}}
+	if len(compiled.conditions) > 0 {
+		match["$and"] = compiled.conditions
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: videoFilter.apply(match)}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$divide": bson.A{
+			bson.M{"$size": bson.M{"$setIntersection": bson.A{"$title_ngrams", grams}}},
+			len(grams),
+		}}}}},
+		{{Key: "$match", Value: bson.M{"score": bson.M{"$gte": fuzzyMinSimilarity}}}},
+	}
+}
+
+// Sort names of fuzzy result pages carry this prefix, so their cursors
+// can't continue an exact search and the other way round
+const fuzzySortPrefix = "fuzzy:"
+
+// IsFuzzyCursor reports whether a cursor points into SearchFuzzy results
+func IsFuzzyCursor(cursor *Cursor) bool {
+	return cursor != nil && strings.HasPrefix(cursor.Sort, fuzzySortPrefix)
+}
+
+// SearchFuzzy finds videos whose titles approximately match the query
+// (typos such as "criket" or "footbal"), ranked by trigram similarity
+func (r *VideoRepository) SearchFuzzy(query *search.Query, pagination Pagination, sortBy string, videoFilter VideoFilter) ([]models.Video, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	sortOptions := append(bson.D{{Key: "score", Value: -1}}, r.buildSortOptions(sortBy)...)
+	pipeline := append(fuzzyStages(query, videoFilter), bson.D{{Key: "$project", Value: bson.M{"title_ngrams": 0}}})
+
+	videos, info, err := aggregatePage[models.Video](ctx, r.collection, pipeline, sortOptions, fuzzySortPrefix+sortBy, pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fuzzy search videos: %w", err)
+	}
+
+	return videos, info, nil
+}
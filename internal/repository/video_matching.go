@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// MatchingIDs returns which of the given videos match a search query and
+// filter, with the same semantics as Search
+func (r *VideoRepository) MatchingIDs(query *search.Query, videoFilter VideoFilter, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := videoFilter.apply(compileQuery(query).filter())
+	filter = bson.M{"$and": []bson.M{filter, {"_id": bson.M{"$in": ids}}}}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to match videos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var matched []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode matched video: %w", err)
+		}
+		matched = append(matched, doc.ID)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read matched videos: %w", err)
+	}
+
+	return matched, nil
+}
+
+// GetByIDs returns the stored videos with the given _ids in sortBy order
+// (relevance falls back to latest)
+func (r *VideoRepository) GetByIDs(ids []primitive.ObjectID, sortBy string) ([]models.Video, error) {
+	videos := []models.Video{}
+	if len(ids) == 0 {
+		return videos, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(r.buildSortOptions(sortBy))
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find videos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &videos); err != nil {
+		return nil, fmt.Errorf("failed to decode videos: %w", err)
+	}
+
+	return videos, nil
+}
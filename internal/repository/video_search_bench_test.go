@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+	"fampay-youtube-api/pkg/database"
+)
+
+// BenchmarkSearch measures the first page of VideoRepository.Search on a
+// generated collection. It needs MongoDB (MONGODB_URI, default localhost)
+// and seeds SEARCH_BENCH_DOCS videos (default 1,000,000) into the
+// fampay_youtube_bench database once; later runs reuse them.
+//
+//	make bench-search
+func BenchmarkSearch(b *testing.B) {
+	db := benchDatabase(b)
+	videoRepo := NewVideoRepository(db)
+	firstPage := Pagination{Page: 1, PageSize: 12}
+
+	queries := []string{"cricket", "india cricket highlights", `"world cup" final`, "golang tutorial", "c++"}
+	for _, query := range queries {
+		parsed, err := search.ParseQuery(query)
+		if err != nil {
+			b.Fatalf("ParseQuery(%q): %v", query, err)
+		}
+
+		for _, sortBy := range []string{"latest", "relevance"} {
+			b.Run(fmt.Sprintf("%s/%s", query, sortBy), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, _, err := videoRepo.Search(parsed, firstPage, sortBy, VideoFilter{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+var benchVocabulary = strings.Fields(`cricket football tennis match highlights final world cup india
+australia england pakistan innings wicket century goal penalty league season review unboxing
+tutorial programming golang python javascript music live stream news update trailer official
+reaction podcast interview cooking recipe travel vlog gaming minecraft fortnite shorts comedy
+science space rocket launch election budget market stocks crypto fitness workout yoga running`)
+
+func benchDatabase(b *testing.B) *mongo.Database {
+	b.Helper()
+
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	docs := 1000000
+	if value := os.Getenv("SEARCH_BENCH_DOCS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			b.Fatalf("invalid SEARCH_BENCH_DOCS %q", value)
+		}
+		docs = n
+	}
+
+	db, err := database.NewMongoDB(config.MongoDBConfig{URI: uri, Database: "fampay_youtube_bench"})
+	if err != nil {
+		b.Skipf("MongoDB not available: %v", err)
+	}
+	b.Cleanup(func() { db.Client().Disconnect(context.Background()) })
+
+	if err := seedBenchVideos(db.Collection("videos"), docs); err != nil {
+		b.Fatalf("failed to seed benchmark videos: %v", err)
+	}
+	return db
+}
+
+// seedBenchVideos tops the collection up to n generated videos with their
+// search fields
+func seedBenchVideos(collection *mongo.Collection, n int) error {
+	ctx := context.Background()
+
+	existing, err := collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	words := func(count int) string {
+		picked := make([]string, count)
+		for i := range picked {
+			picked[i] = benchVocabulary[rng.Intn(len(benchVocabulary))]
+		}
+		if rng.Intn(50) == 0 {
+			picked[rng.Intn(count)] = "c++"
+		}
+		return strings.Join(picked, " ")
+	}
+
+	const batchSize = 5000
+	base := time.Now().Add(-365 * 24 * time.Hour)
+	for i := int(existing); i < n; i += batchSize {
+		batch := make([]interface{}, 0, batchSize)
+		for j := i; j < n && j < i+batchSize; j++ {
+			video := models.Video{
+				VideoID:      fmt.Sprintf("bench%08d", j),
+				Title:        words(4 + rng.Intn(6)),
+				Description:  words(20 + rng.Intn(40)),
+				PublishedAt:  base.Add(time.Duration(rng.Int63n(int64(365 * 24 * time.Hour)))),
+				ChannelTitle: "Channel " + words(1),
+				ChannelID:    fmt.Sprintf("UCbench%04d", rng.Intn(5000)),
+				SearchQuery:  "bench",
+			}
+			applySearchFields(&video)
+			batch = append(batch, video)
+		}
+		if _, err := collection.InsertMany(ctx, batch, options.InsertMany().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Videos updated per bulk write when rebuilding derived search fields
+const reindexBatchSize = 500
+
+// applySearchFields derives the fields search relies on from a video's
+// text: title trigrams, normalized title and description, and language
+// (declared on YouTube, otherwise detected)
+func applySearchFields(video *models.Video) {
+	video.TitleNgrams = search.Trigrams(video.Title)
+	video.Normalized = &models.NormalizedText{
+		Title:       search.Normalize(video.Title),
+		Description: search.Normalize(video.Description),
+	}
+
+	video.Language = ""
+	if video.AudioLanguage != "" {
+		video.Language, _ = search.LanguageCode(video.AudioLanguage)
+	}
+	if video.Language == "" {
+		video.Language = search.DetectLanguage(video.Title, video.Description)
+	}
+}
+
+// ReindexSearchFields rebuilds title trigrams, normalized text and
+// language. Only videos missing one of them are updated unless all is set,
+// e.g. after changing the tokenizer or normalization.
+func (r *VideoRepository) ReindexSearchFields(all bool) (int64, error) {
+	ctx := context.Background()
+
+	filter := bson.M{}
+	if !all {
+		filter = bson.M{"$or": []bson.M{
+			{"title_ngrams": bson.M{"$exists": false}},
+			{"normalized": bson.M{"$exists": false}},
+			{"lang": bson.M{"$exists": false}},
+		}}
+	}
+
+	projection := bson.M{"title": 1, "description": 1, "audio_language": 1}
+	findOptions := options.Find().SetProjection(projection).SetBatchSize(reindexBatchSize)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find videos to reindex: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	writes := make([]mongo.WriteModel, 0, reindexBatchSize)
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		result, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("failed to store search fields: %w", err)
+		}
+		updated += result.ModifiedCount
+		writes = writes[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		var video models.Video
+		if err := cursor.Decode(&video); err != nil {
+			return updated, fmt.Errorf("failed to decode video: %w", err)
+		}
+
+		applySearchFields(&video)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": video.ID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"title_ngrams": video.TitleNgrams,
+				"normalized":   video.Normalized,
+				"lang":         video.Language,
+			}}))
+		if len(writes) == reindexBatchSize {
+			if err := flush(); err != nil {
+				return updated, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return updated, fmt.Errorf("failed to iterate videos: %w", err)
+	}
+
+	return updated, flush()
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// ReindexSearchTerms recomputes the synonym markers of every video with
+// tokens, writing only videos whose markers changed
+func (r *VideoRepository) ReindexSearchTerms(tokens func(video *models.Video) []string) (int64, error) {
+	ctx := context.Background()
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"title": 1, "description": 1, "search_terms": 1}).
+		SetBatchSize(reindexBatchSize)
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find videos to reindex: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	writes := make([]mongo.WriteModel, 0, reindexBatchSize)
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		result, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("failed to store search terms: %w", err)
+		}
+		updated += result.ModifiedCount
+		writes = writes[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		var video models.Video
+		if err := cursor.Decode(&video); err != nil {
+			return updated, fmt.Errorf("failed to decode video: %w", err)
+		}
+
+		terms := tokens(&video)
+		if equalStrings(terms, video.SearchTerms) {
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"search_terms": terms}}
+		if len(terms) == 0 {
+			update = bson.M{"$unset": bson.M{"search_terms": ""}}
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": video.ID}).SetUpdate(update))
+		if len(writes) == reindexBatchSize {
+			if err := flush(); err != nil {
+				return updated, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return updated, fmt.Errorf("failed to iterate videos: %w", err)
+	}
+
+	return updated, flush()
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTranscriptSearchFilter(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		query  string
+		filter VideoFilter
+		want   bson.M
+	}{
+		{name: "no words", query: "   "},
+		{
+			name:  "every word required",
+			query: "Virat Kohli",
+			want: bson.M{
+				"$text": bson.M{"$search": "virat kohli"},
+				"$and": []bson.M{
+					{"normalized_text": prefixRegex("virat")},
+					{"normalized_text": prefixRegex("kohli")},
+				},
+			},
+		},
+		{
+			// The text index skips stop words and short words
+			name:  "words the text index can't match",
+			query: "of to",
+			want: bson.M{"$and": []bson.M{
+				{"normalized_text": prefixRegex("of")},
+				{"normalized_text": prefixRegex("to")},
+			}},
+		},
+		{
+			// Enrichment fields are left for the match on the video
+			name:   "video filter",
+			query:  "century",
+			filter: VideoFilter{ChannelIDs: []string{"UCa"}, PublishedAfter: &after, Format: "short", Safety: SafetyStrict},
+			want: bson.M{
+				"$text": bson.M{"$search": "century"},
+				"$and": []bson.M{
+					{"$and": []bson.M{{"normalized_text": prefixRegex("century")}}},
+					{"channel_id": bson.M{"$in": []string{"UCa"}}},
+					{"published_at": bson.M{"$gte": after}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transcriptSearchFilter(tt.query, tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("transcriptSearchFilter(%q) =\n  %v\nwant\n  %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
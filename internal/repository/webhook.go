@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fampay-youtube-api/internal/models"
+)
+
+type WebhookRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+func NewWebhookRepository(db *mongo.Database) *WebhookRepository {
+	return &WebhookRepository{
+		db:         db,
+		collection: db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+	}
+}
+
+func (r *WebhookRepository) List() ([]models.Webhook, error) {
+	return r.find(bson.M{})
+}
+
+// ListEnabled returns the webhooks new videos are delivered to
+func (r *WebhookRepository) ListEnabled() ([]models.Webhook, error) {
+	return r.find(bson.M{"enabled": true})
+}
+
+func (r *WebhookRepository) find(filter bson.M) ([]models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.Webhook{}
+	if err = cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetByID(id primitive.ObjectID) (*models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook models.Webhook
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Webhook not found
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, webhook)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	webhook.ID = result.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
+// Update replaces a webhook's settings, returning false when it does not
+// exist. An empty secret keeps the current one. Enabling a webhook clears
+// its failure count and the reason it was disabled.
+func (r *WebhookRepository) Update(webhook *models.Webhook) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"name":        webhook.Name,
+		"url":         webhook.URL,
+		"queries":     webhook.Queries,
+		"channel_ids": webhook.ChannelIDs,
+		"keywords":    webhook.Keywords,
+		"enabled":     webhook.Enabled,
+		"updated_at":  webhook.UpdatedAt,
+	}
+	if webhook.Secret != "" {
+		set["secret"] = webhook.Secret
+	}
+	update := bson.M{"$set": set}
+	if webhook.Enabled {
+		set["consecutive_failures"] = 0
+		update["$unset"] = bson.M{"disabled_at": "", "disabled_reason": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": webhook.ID}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes a webhook and its delivery log, returning false when it
+// does not exist
+func (r *WebhookRepository) Delete(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return true, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	return true, nil
+}
+
+// RecordSuccess resets the failure count after a delivered attempt
+func (r *WebhookRepository) RecordSuccess(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"consecutive_failures": 0,
+		"last_delivered_at":    time.Now(),
+	}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt and disables the webhook once
+// disableAfter attempts in a row have failed. It reports whether this call
+// disabled it.
+func (r *WebhookRepository) RecordFailure(id primitive.ObjectID, disableAfter int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var webhook models.Webhook
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"consecutive_failures": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&webhook)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	if disableAfter <= 0 || !webhook.Enabled || webhook.ConsecutiveFailures < disableAfter {
+		return false, nil
+	}
+
+	// Only the attempt that crosses the threshold disables it
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "enabled": true},
+		bson.M{"$set": bson.M{
+			"enabled":         false,
+			"disabled_at":     time.Now(),
+			"disabled_reason": fmt.Sprintf("%d consecutive failed deliveries", webhook.ConsecutiveFailures),
+		}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to disable webhook: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// EnqueueDeliveries stores new pending deliveries, due immediately
+func (r *WebhookRepository) EnqueueDeliveries(deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.ID.IsZero() {
+			delivery.ID = primitive.NewObjectID()
+		}
+		delivery.Status = models.DeliveryStatusPending
+		delivery.Attempts = []models.WebhookAttempt{}
+		delivery.NextAttemptAt = &now
+		delivery.CreatedAt = now
+		docs = append(docs, delivery)
+	}
+
+	if _, err := r.deliveries.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDelivery returns the pending delivery that has waited longest
+// past its next attempt time, or nil when none is due. The attempt time is
+// pushed back by lease so other instances leave it alone while it is sent.
+func (r *WebhookRepository) ClaimDueDelivery(lease time.Duration) (*models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var delivery models.WebhookDelivery
+	err := r.deliveries.FindOneAndUpdate(ctx,
+		bson.M{"status": models.DeliveryStatusPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// RecordAttempt appends an attempt to a delivery. A nil nextAttemptAt
+// completes the delivery with the given status.
+func (r *WebhookRepository) RecordAttempt(id primitive.ObjectID, attempt models.WebhookAttempt, status string, nextAttemptAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$push": bson.M{"attempts": attempt}}
+	if nextAttemptAt != nil {
+		update["$set"] = bson.M{"status": status, "next_attempt_at": *nextAttemptAt}
+	} else {
+		update["$set"] = bson.M{"status": status, "completed_at": time.Now()}
+		update["$unset"] = bson.M{"next_attempt_at": ""}
+	}
+
+	if _, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// FailPending gives up on a webhook's queued deliveries, e.g. once it is disabled
+func (r *WebhookRepository) FailPending(webhookID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.deliveries.UpdateMany(ctx,
+		bson.M{"webhook_id": webhookID, "status": models.DeliveryStatusPending},
+		bson.M{
+			"$set":   bson.M{"status": models.DeliveryStatusFailed, "completed_at": time.Now()},
+			"$unset": bson.M{"next_attempt_at": ""},
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail pending webhook deliveries: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// GetDeliveries returns a webhook's delivery log, newest first, optionally
+// limited to one status
+func (r *WebhookRepository) GetDeliveries(webhookID primitive.ObjectID, status string, pagination Pagination) ([]models.WebhookDelivery, *PageInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"webhook_id": webhookID}
+	if status != "" {
+		filter["status"] = status
+	}
+	sort := bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+
+	deliveries, info, err := findPage[models.WebhookDelivery](ctx, r.deliveries, filter, sort, "latest", pagination, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+
+	return deliveries, info, nil
+}
@@ -0,0 +1,262 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Default highlight settings for search results
+const (
+	DefaultPreTag        = "<mark>"
+	DefaultPostTag       = "</mark>"
+	DefaultSnippetLength = 160
+	fragmentLength       = 80
+	maxDescriptionFrags  = 3
+	ellipsis             = "…"
+)
+
+// Highlighter wraps query matches in tags. Text outside the tags is
+// HTML-escaped after YouTube's entities (&#39;, &amp;quot;) are decoded,
+// so fragments can be rendered as HTML safely.
+type Highlighter struct {
+	PreTag        string
+	PostTag       string
+	SnippetLength int // Runes in the best-snippet window
+
+	terms [][]string // Lowercased words of each term
+}
+
+type span struct {
+	start, end int // Rune offsets
+	term       int
+}
+
+type wordPos struct {
+	start, end int
+	text       string // Lowercased
+}
+
+// NewHighlighter highlights the positive words and phrases of a query
+func NewHighlighter(query *Query, preTag, postTag string, snippetLength int) *Highlighter {
+	h := &Highlighter{PreTag: preTag, PostTag: postTag, SnippetLength: snippetLength}
+	for _, value := range query.HighlightTerms() {
+		if words := Words(value); len(words) > 0 {
+			h.terms = append(h.terms, words)
+		}
+	}
+	return h
+}
+
+// HighlightTerms returns the values of every term that isn't excluded,
+// including field-qualified terms, OR alternatives and synonym variants
+func (q *Query) HighlightTerms() []string {
+	var terms []string
+	var collect func(node Node)
+	collect = func(node Node) {
+		switch n := node.(type) {
+		case Term:
+			if n.Field == FieldAny || n.Field == FieldTitle {
+				terms = append(terms, n.Value)
+			}
+		case Synonym:
+			terms = append(terms, n.Variants...)
+		case Or:
+			for _, clause := range n.Clauses {
+				collect(clause)
+			}
+		}
+	}
+	for _, clause := range q.Clauses {
+		collect(clause)
+	}
+	return terms
+}
+
+// Title returns the whole text with every match highlighted
+func (h *Highlighter) Title(text string) string {
+	runes := []rune(unescapeEntities(text))
+	return h.render(runes, h.matches(runes), 0, len(runes))
+}
+
+// Fragments returns up to three highlighted windows around matches, in
+// text order. Nil when nothing matches.
+func (h *Highlighter) Fragments(text string) []string {
+	runes := []rune(unescapeEntities(text))
+	spans := h.matches(runes)
+
+	var fragments []string
+	end := 0
+	for _, s := range spans {
+		if s.start < end {
+			continue // Already inside the previous fragment
+		}
+		from, to := window(runes, s.start-fragmentLength/3, fragmentLength)
+		if from < end {
+			from = end
+		}
+		fragments = append(fragments, h.render(runes, spans, from, to))
+		end = to
+		if len(fragments) == maxDescriptionFrags {
+			break
+		}
+	}
+	return fragments
+}
+
+// Snippet returns the window of SnippetLength runes covering the most
+// distinct query terms (then the most matches), or the start of the text
+// when nothing matches
+func (h *Highlighter) Snippet(text string) string {
+	runes := []rune(unescapeEntities(text))
+	spans := h.matches(runes)
+
+	length := h.SnippetLength
+	if length <= 0 {
+		length = DefaultSnippetLength
+	}
+
+	bestFrom, bestTo := window(runes, 0, length)
+	bestTerms, bestMatches := 0, 0
+	for _, s := range spans {
+		from, to := window(runes, s.start-length/4, length)
+
+		terms := make(map[int]bool)
+		matches := 0
+		for _, other := range spans {
+			if other.start >= from && other.end <= to {
+				terms[other.term] = true
+				matches++
+			}
+		}
+		if len(terms) > bestTerms || (len(terms) == bestTerms && matches > bestMatches) {
+			bestFrom, bestTo, bestTerms, bestMatches = from, to, len(terms), matches
+		}
+	}
+
+	return h.render(runes, spans, bestFrom, bestTo)
+}
+
+// matches finds words (or runs of words for phrases) starting with each
+// term's words, mirroring the prefix matching of the search filter
+func (h *Highlighter) matches(runes []rune) []span {
+	words := wordPositions(runes)
+
+	var spans []span
+	for i := range words {
+		for termIndex, term := range h.terms {
+			if i+len(term) > len(words) {
+				continue
+			}
+			matched := true
+			for j, termWord := range term {
+				if !strings.HasPrefix(words[i+j].text, termWord) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				spans = append(spans, span{start: words[i].start, end: words[i+len(term)-1].end, term: termIndex})
+				break
+			}
+		}
+	}
+
+	// Drop spans overlapping an earlier one (phrases covering later words)
+	merged := spans[:0]
+	for _, s := range spans {
+		if len(merged) > 0 && s.start < merged[len(merged)-1].end {
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// render escapes runes[from:to], wraps the spans inside it in tags and
+// marks cut-off text with ellipses
+func (h *Highlighter) render(runes []rune, spans []span, from, to int) string {
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString(ellipsis)
+	}
+
+	pos := from
+	for _, s := range spans {
+		if s.start < from || s.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s.start])))
+		b.WriteString(h.PreTag)
+		b.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		b.WriteString(h.PostTag)
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+
+	if to < len(runes) {
+		b.WriteString(ellipsis)
+	}
+	return b.String()
+}
+
+func wordPositions(runes []rune) []wordPos {
+	var words []wordPos
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		inWord := i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsNumber(runes[i]) || unicode.IsMark(runes[i]))
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			words = append(words, wordPos{start: start, end: i, text: Normalize(string(runes[start:i]))})
+			start = -1
+		}
+	}
+	return words
+}
+
+// window returns a range of about length runes starting near from,
+// widened to whole words and with surrounding whitespace trimmed
+func window(runes []rune, from, length int) (int, int) {
+	if from < 0 {
+		from = 0
+	}
+	to := from + length
+	if to > len(runes) {
+		to = len(runes)
+		from = to - length
+		if from < 0 {
+			from = 0
+		}
+	}
+
+	// Don't cut words in half
+	for from > 0 && !unicode.IsSpace(runes[from-1]) {
+		from--
+	}
+	for to < len(runes) && !unicode.IsSpace(runes[to]) {
+		to++
+	}
+
+	for from < to && unicode.IsSpace(runes[from]) {
+		from++
+	}
+	for to > from && unicode.IsSpace(runes[to-1]) {
+		to--
+	}
+	return from, to
+}
+
+// unescapeEntities decodes HTML entities in YouTube titles and
+// descriptions, including double-escaped ones such as &amp;#39;
+func unescapeEntities(text string) string {
+	for i := 0; i < 2; i++ {
+		unescaped := html.UnescapeString(text)
+		if unescaped == text {
+			break
+		}
+		text = unescaped
+	}
+	return text
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestHighlighter(t *testing.T, raw string, snippetLength int) *Highlighter {
+	t.Helper()
+	query, err := ParseQuery(raw)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", raw, err)
+	}
+	return NewHighlighter(query, "[", "]", snippetLength)
+}
+
+func TestHighlighterTitle(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		want  string
+	}{
+		{"cricket", "India Cricket Highlights", "India [Cricket] Highlights"},
+		// Words match by prefix, like the search filter
+		{"high", "India Cricket Highlights", "India Cricket [Highlights]"},
+		{`"world cup"`, "ICC World  Cup final", "ICC [World  Cup] final"},
+		{"cafe", "Café Crème", "[Café] Crème"},
+		{"title:final OR semis", "Semis and Final", "[Semis] and [Final]"},
+		// Excluded and channel terms aren't highlighted
+		{"-cricket channel:espn", "Cricket on ESPN", "Cricket on ESPN"},
+		// Entities are decoded, then everything outside the tags is escaped
+		{"roll", "Rock &amp;amp; Roll&#39;s <best>", "Rock &amp; [Roll]&#39;s &lt;best&gt;"},
+		{"tea", "steam", "steam"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := newTestHighlighter(t, tt.query, 0).Title(tt.text); got != tt.want {
+				t.Errorf("Title(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlighterFragments(t *testing.T) {
+	filler := strings.Repeat("lorem ipsum dolor ", 10)
+
+	tests := []struct {
+		name  string
+		query string
+		text  string
+		want  []string
+	}{
+		{
+			name:  "no match",
+			query: "cricket",
+			text:  "Subscribe for more videos",
+		},
+		{
+			name:  "short text",
+			query: "cricket",
+			text:  "Best cricket moments",
+			want:  []string{"Best [cricket] moments"},
+		},
+		{
+			name:  "nearby matches share a fragment",
+			query: "cricket",
+			text:  "cricket and more cricket",
+			want:  []string{"[cricket] and more [cricket]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestHighlighter(t, tt.query, 0).Fragments(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fragments(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("distant matches", func(t *testing.T) {
+		text := "cricket " + filler + "cricket " + filler + "cricket " + filler + "cricket " + filler
+		fragments := newTestHighlighter(t, "cricket", 0).Fragments(text)
+		if len(fragments) != maxDescriptionFrags {
+			t.Fatalf("got %d fragments, want %d: %q", len(fragments), maxDescriptionFrags, fragments)
+		}
+		for i, fragment := range fragments {
+			if strings.Count(fragment, "[cricket]") != 1 {
+				t.Errorf("fragment %d = %q, want one match", i, fragment)
+			}
+			if i > 0 && !strings.HasPrefix(fragment, ellipsis) {
+				t.Errorf("fragment %d = %q, want a leading ellipsis", i, fragment)
+			}
+			if !strings.HasSuffix(fragment, ellipsis) {
+				t.Errorf("fragment %d = %q, want a trailing ellipsis", i, fragment)
+			}
+		}
+	})
+}
+
+func TestHighlighterSnippet(t *testing.T) {
+	filler := strings.Repeat("lorem ipsum dolor ", 10)
+
+	tests := []struct {
+		name          string
+		query         string
+		text          string
+		snippetLength int
+		want          string
+	}{
+		{
+			name:  "no match starts at the beginning",
+			query: "cricket",
+			text:  "one two three four five six",
+			want:  "one two three four five six",
+		},
+		{
+			name:          "cut at word boundaries",
+			query:         "cricket",
+			text:          "one two three four five six",
+			snippetLength: 10,
+			want:          "one two three…",
+		},
+		{
+			// The window with both terms wins over the earlier one with a single term
+			name:          "most distinct terms",
+			query:         "india final",
+			text:          "india india india " + filler + "india won the final",
+			snippetLength: 30,
+			want:          "…ipsum dolor [india] won the [final]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestHighlighter(t, tt.query, tt.snippetLength).Snippet(tt.text); got != tt.want {
+				t.Errorf("Snippet(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// LanguageUndetermined is stored when no language could be recognized
+const LanguageUndetermined = "und"
+
+// Languages identified by their script alone
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Devanagari, "hi"},
+	{unicode.Bengali, "bn"},
+	{unicode.Tamil, "ta"},
+	{unicode.Telugu, "te"},
+	{unicode.Gujarati, "gu"},
+	{unicode.Gurmukhi, "pa"},
+	{unicode.Kannada, "kn"},
+	{unicode.Malayalam, "ml"},
+	{unicode.Oriya, "or"},
+	{unicode.Arabic, "ar"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Han, "zh"},
+}
+
+// Letters only used by Urdu among Arabic-script languages
+var urduLetters = map[rune]bool{'ٹ': true, 'ڈ': true, 'ڑ': true, 'ں': true, 'ے': true, 'ھ': true}
+
+// Frequent function words of languages written in Latin script, including
+// romanised Hindi ("Hinglish") common in Indian titles
+var latinFunctionWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "for", "with", "on", "this", "how", "what", "you", "are", "from", "best", "new"},
+	"hi": {"hai", "hain", "ka", "ki", "ke", "aur", "kya", "nahi", "mein", "se", "ko", "bhi", "yeh", "tha", "kaise", "wala", "wali", "kar", "karo", "sabse", "apna"},
+	"es": {"el", "la", "los", "las", "y", "de", "del", "que", "en", "para", "con", "por", "una", "como", "mejor"},
+	"fr": {"le", "la", "les", "et", "des", "du", "une", "est", "pour", "avec", "dans", "sur", "pas", "qui"},
+	"de": {"der", "die", "das", "und", "ist", "mit", "für", "nicht", "ein", "eine", "auf", "wie", "den"},
+	"pt": {"o", "os", "as", "e", "do", "da", "dos", "das", "que", "em", "para", "com", "uma", "não", "como"},
+}
+
+var latinWordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range latinFunctionWords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the ISO 639-1 language of a video from its text.
+// The dominant non-Latin script decides when there is one; Latin text is
+// attributed by counting function words. Returns LanguageUndetermined
+// when there is no evidence.
+func DetectLanguage(texts ...string) string {
+	scriptCounts := make(map[string]int)
+	latinLetters, urdu := 0, 0
+	var words []string
+
+	for _, text := range texts {
+		for _, r := range text {
+			if urduLetters[r] {
+				urdu++
+			}
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			if unicode.Is(unicode.Latin, r) {
+				latinLetters++
+				continue
+			}
+			for _, sl := range scriptLanguages {
+				if unicode.Is(sl.script, r) {
+					scriptCounts[sl.language]++
+					break
+				}
+			}
+		}
+		words = append(words, Words(text)...)
+	}
+
+	best, bestCount := "", 0
+	for lang, count := range scriptCounts {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+
+	// Japanese text mixes kana with Han characters
+	if best == "zh" && scriptCounts["ja"] > 0 {
+		best = "ja"
+	}
+	if best == "ar" && urdu > 0 {
+		best = "ur"
+	}
+
+	// Non-Latin script with at least as many letters as Latin (brand names
+	// and hashtags are often written in English)
+	if best != "" && bestCount >= latinLetters {
+		return best
+	}
+
+	votes := make(map[string]int)
+	for _, word := range words {
+		for _, lang := range latinWordLanguages[word] {
+			votes[lang]++
+		}
+	}
+
+	latinBest, latinVotes := "", 0
+	for lang, count := range votes {
+		if count > latinVotes || (count == latinVotes && lang < latinBest) {
+			latinBest, latinVotes = lang, count
+		}
+	}
+	if latinBest != "" {
+		return latinBest
+	}
+	if best != "" {
+		return best
+	}
+	return LanguageUndetermined
+}
+
+// LanguageCode reduces a BCP 47 tag ("en-US", "hi-Latn") to its base
+// language code, reporting false for unknown tags
+func LanguageCode(tag string) (string, bool) {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", false
+	}
+	// "und" and similar tags only yield a guessed base language
+	base, confidence := parsed.Base()
+	if confidence != language.Exact {
+		return "", false
+	}
+	return base.String(), true
+}
@@ -0,0 +1,36 @@
+// Package search holds text processing shared by ingestion and the search
+// endpoints.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words splits normalized text (see Normalize) into letter/number runs
+func Words(text string) []string {
+	return strings.FieldsFunc(Normalize(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+	})
+}
+
+// Trigrams returns the distinct trigrams of every word in text. Words are
+// padded with two leading spaces and one trailing space (as in pg_trgm) so
+// word starts weigh more and one- or two-letter words still produce grams.
+func Trigrams(text string) []string {
+	seen := make(map[string]bool)
+	var grams []string
+
+	for _, word := range Words(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			gram := string(padded[i : i+3])
+			if !seen[gram] {
+				seen[gram] = true
+				grams = append(grams, gram)
+			}
+		}
+	}
+
+	return grams
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"How to make tea?", []string{"how", "to", "make", "tea"}},
+		{"IPL 2026: CSK vs MI", []string{"ipl", "2026", "csk", "vs", "mi"}},
+		{"Café-Crème", []string{"cafe", "creme"}},
+		{"#shorts c++", []string{"shorts", "c"}},
+		{"क्रिकेट मैच", []string{"क्रिकेट", "मैच"}},
+		{"  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Words(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"tea", []string{"  t", " te", "tea", "ea "}},
+		{"a", []string{"  a", " a "}},
+		{"Go", []string{"  g", " go", "go "}},
+		// Repeated grams are listed once, in first-seen order
+		{"tea tea", []string{"  t", " te", "tea", "ea "}},
+		{"aaa", []string{"  a", " aa", "aaa", "aa "}},
+		{"Café!", []string{"  c", " ca", "caf", "afe", "fe "}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Trigrams(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Trigrams(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Full Unicode case folding ("Straße" -> "strasse", final sigma -> sigma)
+var folder = cases.Fold()
+
+// Normalize prepares text for indexing and matching: NFKC (full-width and
+// ligature forms become plain letters), full case folding, and removal of
+// accents on Latin, Greek and Cyrillic letters ("Café" -> "cafe"). Marks
+// of other scripts are kept, since Devanagari vowel signs and viramas are
+// part of the word rather than optional accents.
+func Normalize(text string) string {
+	folded := folder.String(norm.NFKC.String(text))
+
+	decomposed := []rune(norm.NFD.String(folded))
+	var b strings.Builder
+	b.Grow(len(folded))
+
+	stripMarks := false
+	for _, r := range decomposed {
+		if unicode.Is(unicode.Mn, r) {
+			if stripMarks {
+				continue
+			}
+		} else {
+			stripMarks = unicode.In(r, unicode.Latin, unicode.Greek, unicode.Cyrillic)
+		}
+		b.WriteRune(r)
+	}
+
+	return norm.NFC.String(b.String())
+}
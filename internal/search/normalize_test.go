@@ -0,0 +1,29 @@
+package search
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Café Crème", "cafe creme"},
+		{"Straße", "strasse"},
+		{"ΣΟΦΟΣ", "σοφοσ"},
+		{"Ёлка", "елка"},
+		// Full-width and ligature forms become plain letters
+		{"ＩＰＬ ２０２６", "ipl 2026"},
+		{"ﬁnal", "final"},
+		// Devanagari vowel signs and viramas are part of the word
+		{"क्रिकेट", "क्रिकेट"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Normalize(tt.text); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Field qualifiers accepted in search queries (field:value)
+const (
+	FieldAny     = "" // Title or description
+	FieldTitle   = "title"
+	FieldChannel = "channel"
+	FieldQuery   = "query" // Background search query that found the video
+	FieldBefore  = "before"
+	FieldAfter   = "after"
+)
+
+// Date layout of before: and after: values
+const DateLayout = "2006-01-02"
+
+// Node is an element of a parsed search query
+type Node interface {
+	node()
+}
+
+// Term matches a word or quoted phrase, optionally limited to one field
+type Term struct {
+	Field  string
+	Value  string
+	Phrase bool
+	Pos    int
+}
+
+// DateBound restricts the publish date (before: is exclusive, after: inclusive)
+type DateBound struct {
+	Field string // FieldBefore or FieldAfter
+	Date  time.Time
+	Pos   int
+}
+
+// Not excludes videos matching its node (-word, -"phrase", -channel:x)
+type Not struct {
+	Node Node
+}
+
+// Or matches videos matching any of its clauses (a OR b)
+type Or struct {
+	Clauses []Node
+}
+
+func (Term) node()      {}
+func (DateBound) node() {}
+func (Not) node()       {}
+func (Or) node()        {}
+
+// Query is a parsed search query; every clause must match
+type Query struct {
+	Raw     string
+	Clauses []Node
+}
+
+// Text returns the words and phrases of positive unqualified terms, the
+// part of the query that describes what the video is about
+func (q *Query) Text() string {
+	var parts []string
+	for _, clause := range q.Clauses {
+		switch term := clause.(type) {
+		case Term:
+			if term.Field == FieldAny {
+				parts = append(parts, term.Value)
+			}
+		case Synonym:
+			if term.Term.Field == FieldAny {
+				parts = append(parts, term.Term.Value)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SyntaxError points at the token of the query that couldn't be parsed
+type SyntaxError struct {
+	Pos     int // 1-based character position
+	Token   string
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d (%q)", e.Message, e.Pos, e.Token)
+}
+
+type token struct {
+	text   string // Raw token text
+	pos    int
+	negate bool
+	field  string
+	value  string
+	phrase bool
+	or     bool
+}
+
+// ParseQuery parses a search query such as
+//
+//	"world cup" -highlights channel:espn after:2026-01-01 cricket OR football
+//
+// Terms are ANDed; OR binds tighter than the implicit AND, so
+// "india cricket OR football" means india AND (cricket OR football).
+func ParseQuery(raw string) (*Query, error) {
+	tokens, err := tokenize(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{Raw: raw}
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].or {
+			return nil, &SyntaxError{Pos: tokens[i].pos, Token: tokens[i].text, Message: "OR must be placed between two terms"}
+		}
+
+		node, err := tokens[i].node()
+		if err != nil {
+			return nil, err
+		}
+
+		// Collect "a OR b OR c"
+		clauses := []Node{node}
+		for i+1 < len(tokens) && tokens[i+1].or {
+			orToken := tokens[i+1]
+			if i+2 >= len(tokens) || tokens[i+2].or {
+				return nil, &SyntaxError{Pos: orToken.pos, Token: orToken.text, Message: "OR must be followed by a term"}
+			}
+			next, err := tokens[i+2].node()
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, next)
+			i += 2
+		}
+
+		if len(clauses) == 1 {
+			query.Clauses = append(query.Clauses, node)
+		} else {
+			query.Clauses = append(query.Clauses, Or{Clauses: clauses})
+		}
+	}
+
+	if len(query.Clauses) == 0 {
+		return nil, &SyntaxError{Pos: 1, Token: raw, Message: "search query is empty"}
+	}
+
+	return query, nil
+}
+
+func (t token) node() (Node, error) {
+	var node Node
+
+	switch t.field {
+	case FieldBefore, FieldAfter:
+		if t.negate {
+			return nil, &SyntaxError{Pos: t.pos, Token: t.text, Message: fmt.Sprintf("%s: cannot be negated", t.field)}
+		}
+		date, err := time.Parse(DateLayout, t.value)
+		if err != nil {
+			return nil, &SyntaxError{Pos: t.pos, Token: t.text, Message: fmt.Sprintf("invalid %s: date (expected YYYY-MM-DD)", t.field)}
+		}
+		node = DateBound{Field: t.field, Date: date, Pos: t.pos}
+	default:
+		node = Term{Field: t.field, Value: t.value, Phrase: t.phrase, Pos: t.pos}
+	}
+
+	if t.negate {
+		node = Not{Node: node}
+	}
+	return node, nil
+}
+
+func knownField(field string) bool {
+	switch field {
+	case FieldTitle, FieldChannel, FieldQuery, FieldBefore, FieldAfter:
+		return true
+	}
+	return false
+}
+
+// tokenize splits the query on whitespace, keeping quoted phrases together
+func tokenize(raw string) ([]token, error) {
+	var tokens []token
+	runes := []rune(raw)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		t := token{pos: start + 1}
+
+		if runes[i] == '-' {
+			t.negate = true
+			i++
+		}
+
+		// field: prefix, only when made of letters so "10:30" stays a word
+		if colon := indexColon(runes, i); colon > i {
+			field := strings.ToLower(string(runes[i:colon]))
+			if !knownField(field) {
+				end := tokenEnd(runes, i)
+				return nil, &SyntaxError{Pos: start + 1, Token: string(runes[start:end]), Message: fmt.Sprintf("unknown field %q (expected title, channel, query, before or after)", field)}
+			}
+			t.field = field
+			i = colon + 1
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			closing := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == '"' {
+					closing = j
+					break
+				}
+			}
+			if closing < 0 {
+				return nil, &SyntaxError{Pos: i + 1, Token: string(runes[start:]), Message: "unterminated quoted phrase"}
+			}
+			t.value = strings.Join(strings.Fields(string(runes[i+1:closing])), " ")
+			t.phrase = true
+			i = closing + 1
+		} else {
+			end := tokenEnd(runes, i)
+			t.value = string(runes[i:end])
+			i = end
+		}
+
+		t.text = string(runes[start:i])
+		if t.text == "OR" {
+			t.or = true
+			tokens = append(tokens, t)
+			continue
+		}
+
+		if strings.TrimSpace(t.value) == "" {
+			message := "empty search term"
+			if t.field != "" {
+				message = fmt.Sprintf("%s: needs a value", t.field)
+			}
+			return nil, &SyntaxError{Pos: start + 1, Token: t.text, Message: message}
+		}
+		if t.phrase && i < len(runes) && !unicode.IsSpace(runes[i]) {
+			end := tokenEnd(runes, i)
+			return nil, &SyntaxError{Pos: i + 1, Token: string(runes[start:end]), Message: "expected a space after the closing quote"}
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+// indexColon returns the position of a ':' ending a run of letters starting at i, or -1
+func indexColon(runes []rune, i int) int {
+	for j := i; j < len(runes); j++ {
+		switch {
+		case runes[j] == ':':
+			return j
+		case !unicode.IsLetter(runes[j]):
+			return -1
+		}
+	}
+	return -1
+}
+
+func tokenEnd(runes []rune, i int) int {
+	for i < len(runes) && !unicode.IsSpace(runes[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	date := func(value string) time.Time {
+		d, _ := time.Parse(DateLayout, value)
+		return d
+	}
+
+	tests := []struct {
+		raw  string
+		want []Node
+	}{
+		{
+			raw:  "india cricket",
+			want: []Node{Term{Value: "india", Pos: 1}, Term{Value: "cricket", Pos: 7}},
+		},
+		{
+			raw:  `"world   cup" final`,
+			want: []Node{Term{Value: "world cup", Phrase: true, Pos: 1}, Term{Value: "final", Pos: 15}},
+		},
+		{
+			raw: `cricket -highlights -"full match"`,
+			want: []Node{
+				Term{Value: "cricket", Pos: 1},
+				Not{Node: Term{Value: "highlights", Pos: 9}},
+				Not{Node: Term{Value: "full match", Phrase: true, Pos: 21}},
+			},
+		},
+		{
+			// OR binds tighter than the implicit AND
+			raw: "india cricket OR football OR hockey",
+			want: []Node{
+				Term{Value: "india", Pos: 1},
+				Or{Clauses: []Node{Term{Value: "cricket", Pos: 7}, Term{Value: "football", Pos: 18}, Term{Value: "hockey", Pos: 30}}},
+			},
+		},
+		{
+			raw: `Title:final channel:"Star Sports" query:ipl -channel:spam`,
+			want: []Node{
+				Term{Field: FieldTitle, Value: "final", Pos: 1},
+				Term{Field: FieldChannel, Value: "Star Sports", Phrase: true, Pos: 13},
+				Term{Field: FieldQuery, Value: "ipl", Pos: 35},
+				Not{Node: Term{Field: FieldChannel, Value: "spam", Pos: 45}},
+			},
+		},
+		{
+			raw: "after:2026-01-01 before:2026-02-01",
+			want: []Node{
+				DateBound{Field: FieldAfter, Date: date("2026-01-01"), Pos: 1},
+				DateBound{Field: FieldBefore, Date: date("2026-02-01"), Pos: 18},
+			},
+		},
+		{
+			// Only letters before a colon make a field
+			raw:  "10:30 or c++",
+			want: []Node{Term{Value: "10:30", Pos: 1}, Term{Value: "or", Pos: 7}, Term{Value: "c++", Pos: 10}},
+		},
+		{
+			raw:  "क्रिकेट मैच",
+			want: []Node{Term{Value: "क्रिकेट", Pos: 1}, Term{Value: "मैच", Pos: 9}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			query, err := ParseQuery(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.raw, err)
+			}
+			if query.Raw != tt.raw {
+				t.Errorf("Raw = %q, want %q", query.Raw, tt.raw)
+			}
+			if !reflect.DeepEqual(query.Clauses, tt.want) {
+				t.Errorf("Clauses =\n  %#v\nwant\n  %#v", query.Clauses, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantPos   int
+		wantToken string
+	}{
+		{"   ", 1, "   "},
+		{"cricket OR", 9, "OR"},
+		{"OR cricket", 1, "OR"},
+		{"cricket OR OR football", 9, "OR"},
+		{`"world cup`, 1, `"world cup`},
+		{`"world cup"final`, 12, `"world cup"final`},
+		{"author:someone", 1, "author:someone"},
+		{"cricket title:", 9, "title:"},
+		{"after:01-02-2026", 1, "after:01-02-2026"},
+		{"-before:2026-01-01", 1, "-before:2026-01-01"},
+		{`cricket ""`, 9, `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			_, err := ParseQuery(tt.raw)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a *SyntaxError", tt.raw, err)
+			}
+			if syntaxErr.Pos != tt.wantPos || syntaxErr.Token != tt.wantToken {
+				t.Errorf("error at %d (%q), want %d (%q): %v", syntaxErr.Pos, syntaxErr.Token, tt.wantPos, tt.wantToken, err)
+			}
+		})
+	}
+}
+
+func TestQueryText(t *testing.T) {
+	query, err := ParseQuery(`"world cup" -highlights channel:espn final OR semis cricket`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := query.Text(), "world cup cricket"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
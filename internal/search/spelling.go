@@ -0,0 +1,267 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// Words shorter than this are never corrected
+	minCorrectLength = 3
+
+	// Longest word kept in the vocabulary
+	maxVocabularyWordLength = 30
+)
+
+// Vocabulary counts the documents each indexed word appears in. It is safe
+// for concurrent use.
+type Vocabulary struct {
+	mu       sync.RWMutex
+	counts   map[string]int64
+	byLength map[int][]string // Words grouped by rune count, for candidate lookup
+}
+
+func NewVocabulary() *Vocabulary {
+	return &Vocabulary{
+		counts:   make(map[string]int64),
+		byLength: make(map[int][]string),
+	}
+}
+
+// VocabularyTerms returns the distinct words of a document worth adding to
+// the vocabulary (no stop words, numbers or very long tokens)
+func VocabularyTerms(texts ...string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, text := range texts {
+		for _, word := range Words(text) {
+			length := len([]rune(word))
+			if seen[word] || length < 2 || length > maxVocabularyWordLength || IsStopWord(word) || isDigits(word) {
+				continue
+			}
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// Add increases the document count of a word
+func (v *Vocabulary) Add(word string, count int64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.counts[word]; !ok {
+		length := len([]rune(word))
+		v.byLength[length] = append(v.byLength[length], word)
+	}
+	v.counts[word] += count
+}
+
+// Len returns the number of distinct words
+func (v *Vocabulary) Len() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.counts)
+}
+
+// Count returns the document count of a word
+func (v *Vocabulary) Count(word string) int64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.counts[word]
+}
+
+// Correct returns the closest known word within the allowed edit distance
+// (one edit up to five letters, two beyond), preferring the most frequent
+// word among equally close ones. Known, short and numeric words are
+// returned unchanged.
+func (v *Vocabulary) Correct(word string) (string, bool) {
+	word = Normalize(word)
+	length := len([]rune(word))
+	if length < minCorrectLength || IsStopWord(word) || isDigits(word) {
+		return word, false
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.counts[word] > 0 {
+		return word, false
+	}
+
+	maxDistance := 1
+	if length > 5 {
+		maxDistance = 2
+	}
+
+	best, bestDistance, bestCount := "", maxDistance+1, int64(0)
+	for l := length - maxDistance; l <= length+maxDistance; l++ {
+		for _, candidate := range v.byLength[l] {
+			distance := EditDistance(word, candidate, bestDistance)
+			count := v.counts[candidate]
+			if distance < bestDistance || (distance == bestDistance && (count > bestCount || (count == bestCount && candidate < best))) {
+				best, bestDistance, bestCount = candidate, distance, count
+			}
+		}
+	}
+
+	if best == "" || bestDistance > maxDistance {
+		return word, false
+	}
+	return best, true
+}
+
+// EditDistance is the optimal string alignment distance between a and b
+// (insertions, deletions, substitutions and adjacent transpositions). Once
+// the distance is known to exceed max it returns max+1.
+func EditDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	// Three rows: two back (for transpositions), previous and current
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minOf(curr[j], prev2[j-2]+1)
+			}
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	if prev[len(rb)] > max {
+		return max + 1
+	}
+	return prev[len(rb)]
+}
+
+// CorrectQuery rewrites the words of title and unqualified terms in the raw
+// query with correct, keeping operators, quotes and other fields as typed.
+// It reports whether any word changed.
+func CorrectQuery(query *Query, correct func(word string) (string, bool)) (string, bool) {
+	runes := []rune(query.Raw)
+	replacements := make(map[int]correction) // Start rune index -> replacement
+
+	var visit func(node Node)
+	visit = func(node Node) {
+		switch n := node.(type) {
+		case Term:
+			if n.Field != FieldAny && n.Field != FieldTitle {
+				return
+			}
+			start, end := termValueSpan(runes, n)
+			for i := start; i < end; {
+				if !isWordRune(runes[i]) {
+					i++
+					continue
+				}
+				j := i
+				for j < end && isWordRune(runes[j]) {
+					j++
+				}
+				if corrected, changed := correct(Normalize(string(runes[i:j]))); changed {
+					replacements[i] = correction{end: j, text: corrected}
+				}
+				i = j
+			}
+		case Not:
+			visit(n.Node)
+		case Or:
+			for _, clause := range n.Clauses {
+				visit(clause)
+			}
+		}
+	}
+	for _, clause := range query.Clauses {
+		visit(clause)
+	}
+
+	if len(replacements) == 0 {
+		return query.Raw, false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if r, ok := replacements[i]; ok {
+			b.WriteString(r.text)
+			i = r.end
+			continue
+		}
+		b.WriteRune(runes[i])
+		i++
+	}
+	return b.String(), true
+}
+
+type correction struct {
+	end  int
+	text string
+}
+
+// termValueSpan locates a term's value in the raw query, skipping the
+// leading '-', field: prefix and quotes
+func termValueSpan(runes []rune, term Term) (int, int) {
+	i := term.Pos - 1
+	if i < len(runes) && runes[i] == '-' {
+		i++
+	}
+	if term.Field != FieldAny {
+		if colon := indexColon(runes, i); colon >= i {
+			i = colon + 1
+		}
+	}
+	if term.Phrase && i < len(runes) && runes[i] == '"' {
+		end := i + 1
+		for end < len(runes) && runes[end] != '"' {
+			end++
+		}
+		return i + 1, end
+	}
+	return i, tokenEnd(runes, i)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+func isDigits(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func minOf(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"same", "same", 0, 0},
+		{"kitten", "sitting", 3, 3},
+		{"cricket", "crikcet", 2, 1}, // Adjacent transposition
+		{"café", "cafe", 1, 1},       // Runes, not bytes
+		{"", "abc", 3, 3},
+		// Past max the exact distance doesn't matter
+		{"abc", "", 1, 2},
+		{"cricket", "football", 2, 3},
+		{"kitten", "sitting", 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := EditDistance(tt.a, tt.b, tt.max); got != tt.want {
+				t.Errorf("EditDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestVocabulary() *Vocabulary {
+	vocabulary := NewVocabulary()
+	for word, count := range map[string]int64{
+		"cricket":  10,
+		"crickets": 2,
+		"football": 5,
+		"india":    3,
+		"tennis":   1,
+		"match":    4,
+		"batch":    1,
+	} {
+		vocabulary.Add(word, count)
+	}
+	return vocabulary
+}
+
+func TestVocabularyCorrect(t *testing.T) {
+	vocabulary := newTestVocabulary()
+
+	tests := []struct {
+		word        string
+		want        string
+		wantChanged bool
+	}{
+		{"crikcet", "cricket", true},
+		{"indai", "india", true},
+		// Two edits are allowed beyond five letters
+		{"fotbal", "football", true},
+		{"tnns", "tnns", false},
+		// The more frequent of equally close words wins
+		{"xatch", "match", true},
+		// Known, short, numeric and stop words are left alone
+		{"Cricket", "cricket", false},
+		{"crickets", "crickets", false},
+		{"ab", "ab", false},
+		{"2026", "2026", false},
+		{"the", "the", false},
+		{"xyzzy", "xyzzy", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, changed := vocabulary.Correct(tt.word)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("Correct(%q) = %q, %v, want %q, %v", tt.word, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestVocabularyTerms(t *testing.T) {
+	got := VocabularyTerms("The Cricket World Cup 2026", "cricket highlights a")
+	want := []string{"cricket", "world", "cup", "highlights"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VocabularyTerms() = %q, want %q", got, want)
+	}
+}
+
+func TestCorrectQuery(t *testing.T) {
+	vocabulary := newTestVocabulary()
+
+	tests := []struct {
+		raw         string
+		want        string
+		wantChanged bool
+	}{
+		{"cricket india", "cricket india", false},
+		{"crikcet  indai", "cricket  india", true},
+		// Operators, quotes and untouched fields are kept as typed
+		{`-fotbal "indai final" OR tennis`, `-football "india final" OR tennis`, true},
+		{"title:crikcet channel:crikcet", "title:cricket channel:crikcet", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			query, err := ParseQuery(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.raw, err)
+			}
+			got, changed := CorrectQuery(query, vocabulary.Correct)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("CorrectQuery(%q) = %q, %v, want %q, %v", tt.raw, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
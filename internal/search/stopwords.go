@@ -0,0 +1,18 @@
+package search
+
+// Common English words dropped by the Mongo text index. They carry no
+// meaning on their own, so search and suggestions treat them separately.
+var stopWords = map[string]bool{
+	"a": true, "about": true, "an": true, "and": true, "are": true, "as": true,
+	"at": true, "be": true, "but": true, "by": true, "for": true, "from": true,
+	"has": true, "have": true, "how": true, "in": true, "is": true, "it": true,
+	"its": true, "not": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "this": true, "to": true, "vs": true, "was": true,
+	"what": true, "when": true, "where": true, "which": true, "who": true,
+	"why": true, "will": true, "with": true, "you": true, "your": true,
+}
+
+// IsStopWord reports whether a lowercased word is a stop word
+func IsStopWord(word string) bool {
+	return stopWords[word]
+}
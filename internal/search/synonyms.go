@@ -0,0 +1,144 @@
+package search
+
+import (
+	"sort"
+	"strings"
+)
+
+// Synonym is a term that belongs to a synonym group. It matches any of the
+// group's variants ("kriket", "क्रिकेट", "cricket"); the text index matches
+// it through Token, which is stored on every video containing a variant.
+type Synonym struct {
+	Term     Term
+	Variants []string
+	Token    string
+}
+
+func (Synonym) node() {}
+
+// SynonymGroup is a set of spellings, scripts or words with one meaning
+type SynonymGroup struct {
+	Token string // Single-word marker indexed in search_terms
+	Terms []string
+}
+
+// SynonymToken derives the search_terms marker of a group from its ID. It
+// is one lowercase alphanumeric word so the text index keeps it intact.
+func SynonymToken(id string) string {
+	return "syn" + strings.ToLower(id)
+}
+
+// Dictionary looks up the synonym group of normalized words and phrases
+type Dictionary struct {
+	groups []SynonymGroup
+	lookup map[string]int // Normalized term -> group index
+}
+
+func NewDictionary(groups []SynonymGroup) *Dictionary {
+	d := &Dictionary{lookup: make(map[string]int)}
+	for _, group := range groups {
+		normalized := SynonymGroup{Token: group.Token}
+		for _, term := range group.Terms {
+			key := normalizeTerm(term)
+			if key == "" {
+				continue
+			}
+			if _, exists := d.lookup[key]; exists {
+				continue // A term belongs to the first group listing it
+			}
+			d.lookup[key] = len(d.groups)
+			normalized.Terms = append(normalized.Terms, key)
+		}
+		if len(normalized.Terms) > 1 {
+			d.groups = append(d.groups, normalized)
+		} else {
+			for _, term := range normalized.Terms {
+				delete(d.lookup, term)
+			}
+		}
+	}
+	return d
+}
+
+// Len returns the number of usable groups (at least two terms)
+func (d *Dictionary) Len() int {
+	return len(d.groups)
+}
+
+// Expand returns a copy of the query with every title or unqualified term
+// found in the dictionary replaced by a Synonym node
+func (d *Dictionary) Expand(query *Query) *Query {
+	if len(d.groups) == 0 {
+		return query
+	}
+
+	expanded := &Query{Raw: query.Raw, Clauses: make([]Node, 0, len(query.Clauses))}
+	for _, clause := range query.Clauses {
+		expanded.Clauses = append(expanded.Clauses, d.expandNode(clause))
+	}
+	return expanded
+}
+
+func (d *Dictionary) expandNode(node Node) Node {
+	switch n := node.(type) {
+	case Term:
+		if n.Field != FieldAny && n.Field != FieldTitle {
+			return n
+		}
+		index, ok := d.lookup[normalizeTerm(n.Value)]
+		if !ok {
+			return n
+		}
+		group := d.groups[index]
+		return Synonym{Term: n, Variants: group.Terms, Token: group.Token}
+	case Not:
+		return Not{Node: d.expandNode(n.Node)}
+	case Or:
+		clauses := make([]Node, 0, len(n.Clauses))
+		for _, clause := range n.Clauses {
+			clauses = append(clauses, d.expandNode(clause))
+		}
+		return Or{Clauses: clauses}
+	}
+	return node
+}
+
+// Tokens returns the markers of every group with a variant in the texts
+func (d *Dictionary) Tokens(texts ...string) []string {
+	if len(d.groups) == 0 {
+		return nil
+	}
+
+	var words []string
+	for _, text := range texts {
+		words = append(words, Words(text)...)
+	}
+	joined := " " + strings.Join(words, " ") + " "
+
+	seen := make(map[int]bool)
+	var tokens []string
+	for _, word := range words {
+		if index, ok := d.lookup[word]; ok && !seen[index] {
+			seen[index] = true
+			tokens = append(tokens, d.groups[index].Token)
+		}
+	}
+
+	// Multi-word variants ("world cup") are matched as whole phrases
+	for term, index := range d.lookup {
+		if seen[index] || !strings.Contains(term, " ") {
+			continue
+		}
+		if strings.Contains(joined, " "+term+" ") {
+			seen[index] = true
+			tokens = append(tokens, d.groups[index].Token)
+		}
+	}
+
+	sort.Strings(tokens)
+	return tokens
+}
+
+func normalizeTerm(term string) string {
+	return strings.Join(Words(term), " ")
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestDictionary() *Dictionary {
+	return NewDictionary([]SynonymGroup{
+		{Token: "syncricket", Terms: []string{"Cricket", "kriket", "क्रिकेट"}},
+		{Token: "synworldcup", Terms: []string{"World Cup", "WC"}},
+		// "cricket" already belongs to the first group, leaving one usable term
+		{Token: "synunused", Terms: []string{"cricket", "   "}},
+	})
+}
+
+func TestNewDictionary(t *testing.T) {
+	if got := newTestDictionary().Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestDictionaryExpand(t *testing.T) {
+	dictionary := newTestDictionary()
+
+	tests := []struct {
+		raw  string
+		want []Node
+	}{
+		{
+			raw: "KRIKET final",
+			want: []Node{
+				Synonym{Term: Term{Value: "KRIKET", Pos: 1}, Variants: []string{"cricket", "kriket", "क्रिकेट"}, Token: "syncricket"},
+				Term{Value: "final", Pos: 8},
+			},
+		},
+		{
+			raw: `"world cup" -wc`,
+			want: []Node{
+				Synonym{Term: Term{Value: "world cup", Phrase: true, Pos: 1}, Variants: []string{"world cup", "wc"}, Token: "synworldcup"},
+				Not{Node: Synonym{Term: Term{Value: "wc", Pos: 13}, Variants: []string{"world cup", "wc"}, Token: "synworldcup"}},
+			},
+		},
+		{
+			// Only title and unqualified terms are expanded
+			raw: "channel:cricket title:kriket",
+			want: []Node{
+				Term{Field: FieldChannel, Value: "cricket", Pos: 1},
+				Synonym{Term: Term{Field: FieldTitle, Value: "kriket", Pos: 17}, Variants: []string{"cricket", "kriket", "क्रिकेट"}, Token: "syncricket"},
+			},
+		},
+		{
+			raw:  "world football",
+			want: []Node{Term{Value: "world", Pos: 1}, Term{Value: "football", Pos: 7}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			query, err := ParseQuery(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.raw, err)
+			}
+			if got := dictionary.Expand(query).Clauses; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expand(%q) =\n  %#v\nwant\n  %#v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDictionaryTokens(t *testing.T) {
+	dictionary := newTestDictionary()
+
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{name: "word", texts: []string{"Kriket highlights"}, want: []string{"syncricket"}},
+		{name: "phrase across texts", texts: []string{"ICC World", "Cup final: क्रिकेट"}, want: []string{"syncricket", "synworldcup"}},
+		// Phrases only match as whole words
+		{name: "partial phrase", texts: []string{"world cupcake"}},
+		{name: "none", texts: []string{"football"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dictionary.Tokens(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokens(%q) = %q, want %q", tt.texts, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Caption file formats understood by parseCaptions
+const (
+	CaptionFormatTimedText = "xml" // YouTube timedtext (srv1 or srv3)
+	CaptionFormatWebVTT    = "vtt"
+	CaptionFormatSRT       = "srt"
+)
+
+var (
+	captionTagPattern        = regexp.MustCompile(`<[^>]*>`)
+	captionWhitespacePattern = regexp.MustCompile(`\s+`)
+	captionCuePattern        = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})`)
+)
+
+// parseCaptions converts a caption file into transcript segments
+func parseCaptions(data []byte, format string) ([]models.TranscriptSegment, error) {
+	switch format {
+	case CaptionFormatTimedText:
+		return parseTimedText(data)
+	case CaptionFormatWebVTT, CaptionFormatSRT:
+		return parseCues(data)
+	default:
+		return nil, fmt.Errorf("unsupported caption format %q", format)
+	}
+}
+
+// srv1: <transcript><text start="1.2" dur="3.4">...</text></transcript>
+// srv3: <timedtext><body><p t="1200" d="3400">...</p></body></timedtext>
+type timedTextDocument struct {
+	Texts      []timedTextLine `xml:"text"`
+	Paragraphs []timedTextLine `xml:"body>p"`
+}
+
+type timedTextLine struct {
+	Start    string `xml:"start,attr"`
+	Dur      string `xml:"dur,attr"`
+	T        string `xml:"t,attr"`
+	D        string `xml:"d,attr"`
+	InnerXML string `xml:",innerxml"`
+}
+
+func parseTimedText(data []byte) ([]models.TranscriptSegment, error) {
+	var doc timedTextDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid timedtext XML: %w", err)
+	}
+
+	var segments []models.TranscriptSegment
+	for _, line := range doc.Texts {
+		start, _ := strconv.ParseFloat(line.Start, 64)
+		duration, _ := strconv.ParseFloat(line.Dur, 64)
+		segments = appendSegment(segments, start, duration, line.InnerXML)
+	}
+	for _, line := range doc.Paragraphs {
+		startMs, _ := strconv.ParseFloat(line.T, 64)
+		durationMs, _ := strconv.ParseFloat(line.D, 64)
+		segments = appendSegment(segments, startMs/1000, durationMs/1000, line.InnerXML)
+	}
+
+	return segments, nil
+}
+
+// parseCues handles WebVTT and SRT, which share the "start --> end" cue layout
+func parseCues(data []byte) ([]models.TranscriptSegment, error) {
+	var segments []models.TranscriptSegment
+	var start, end float64
+	var text []string
+	inCue := false
+
+	flush := func() {
+		if inCue {
+			segments = appendSegment(segments, start, end-start, strings.Join(text, " "))
+		}
+		inCue = false
+		text = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if matches := captionCuePattern.FindStringSubmatch(line); matches != nil {
+			flush()
+			start = parseCueTimestamp(matches[1])
+			end = parseCueTimestamp(matches[2])
+			inCue = true
+			continue
+		}
+
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if inCue {
+			text = append(text, line)
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read captions: %w", err)
+	}
+
+	return segments, nil
+}
+
+// parseCueTimestamp converts "01:02:03.456", "02:03.456" or "00:02:03,456" to seconds
+func parseCueTimestamp(value string) float64 {
+	value = strings.Replace(value, ",", ".", 1)
+	parts := strings.Split(value, ":")
+
+	var seconds float64
+	for _, part := range parts {
+		n, _ := strconv.ParseFloat(part, 64)
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
+
+func appendSegment(segments []models.TranscriptSegment, start, duration float64, raw string) []models.TranscriptSegment {
+	text := cleanCaptionText(raw)
+	if text == "" {
+		return segments
+	}
+
+	return append(segments, models.TranscriptSegment{
+		Start:          start,
+		Duration:       duration,
+		Text:           text,
+		NormalizedText: search.Normalize(text),
+	})
+}
+
+// cleanCaptionText strips markup and decodes entities (timedtext is often double-escaped)
+func cleanCaptionText(raw string) string {
+	text := captionTagPattern.ReplaceAllString(raw, " ")
+	text = html.UnescapeString(html.UnescapeString(text))
+	text = captionTagPattern.ReplaceAllString(text, " ")
+	return strings.TrimSpace(captionWhitespacePattern.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCaptions(t *testing.T) {
+	type segment struct {
+		start, duration  float64
+		text, normalized string
+	}
+
+	tests := []struct {
+		file   string
+		format string
+		want   []segment
+	}{
+		{
+			file:   "sample.vtt",
+			format: CaptionFormatWebVTT,
+			want: []segment{
+				{1, 3.5, "Welcome to the final over", "welcome to the final over"},
+				{4.5, 2.75, "Six needed off the last ball & he goes big!", "six needed off the last ball & he goes big!"},
+				{3723, 2.5, "Café crowd on its feet", "cafe crowd on its feet"},
+			},
+		},
+		{
+			file:   "sample.srt",
+			format: CaptionFormatSRT,
+			want: []segment{
+				{1, 3.5, "Welcome to the final over", "welcome to the final over"},
+				{4.5, 2.75, "Six needed off the last ball", "six needed off the last ball"},
+			},
+		},
+		{
+			file:   "srv1.xml",
+			format: CaptionFormatTimedText,
+			want: []segment{
+				{0.5, 2.1, "Welcome to the 'final' over", "welcome to the 'final' over"},
+				{2.6, 3, "Six needed & he goes big", "six needed & he goes big"},
+			},
+		},
+		{
+			file:   "srv3.xml",
+			format: CaptionFormatTimedText,
+			want: []segment{
+				{1.2, 3.4, "Welcome to the FINAL", "welcome to the final"},
+				{4.6, 2.65, "Crowd on its feet", "crowd on its feet"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "captions", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			segments, err := parseCaptions(data, tt.format)
+			if err != nil {
+				t.Fatalf("parseCaptions: %v", err)
+			}
+			if len(segments) != len(tt.want) {
+				t.Fatalf("got %d segments, want %d: %+v", len(segments), len(tt.want), segments)
+			}
+
+			for i, want := range tt.want {
+				got := segments[i]
+				if !closeTo(got.Start, want.start) || !closeTo(got.Duration, want.duration) {
+					t.Errorf("segment %d timing = %v+%v, want %v+%v", i, got.Start, got.Duration, want.start, want.duration)
+				}
+				if got.Text != want.text {
+					t.Errorf("segment %d text = %q, want %q", i, got.Text, want.text)
+				}
+				if got.NormalizedText != want.normalized {
+					t.Errorf("segment %d normalized text = %q, want %q", i, got.NormalizedText, want.normalized)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCaptionsErrors(t *testing.T) {
+	if _, err := parseCaptions([]byte("<transcript><text"), CaptionFormatTimedText); err == nil {
+		t.Error("parseCaptions accepted malformed timedtext XML")
+	}
+	if _, err := parseCaptions([]byte("WEBVTT"), "ttml"); err == nil {
+		t.Error("parseCaptions accepted an unsupported format")
+	}
+}
+
+func TestParseCueTimestamp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+	}{
+		{"00:00:01.500", 1.5},
+		{"02:03.456", 123.456},
+		{"01:02:03,250", 3723.25},
+		{"10:00:00.000", 36000},
+	}
+
+	for _, tt := range tests {
+		if got := parseCueTimestamp(tt.value); !closeTo(got, tt.want) {
+			t.Errorf("parseCueTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	const epsilon = 1e-9
+	return a-b < epsilon && b-a < epsilon
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// CaptionSource fetches the transcript of a video in the first available
+// preferred language. It returns no segments when no captions exist.
+type CaptionSource interface {
+	FetchTranscript(videoID string, languages []string) (string, []models.TranscriptSegment, error)
+}
+
+// YouTubeCaptionSource lists caption tracks with Captions.List (50 quota
+// units) and downloads them from the public timedtext endpoint, since
+// Captions.Download requires OAuth as the video owner.
+type YouTubeCaptionSource struct {
+	youtubeService *YouTubeService
+	httpClient     *http.Client
+	timedTextURL   string
+}
+
+func NewYouTubeCaptionSource(youtubeService *YouTubeService) *YouTubeCaptionSource {
+	return &YouTubeCaptionSource{
+		youtubeService: youtubeService,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		timedTextURL:   "https://www.youtube.com/api/timedtext",
+	}
+}
+
+func (ycs *YouTubeCaptionSource) FetchTranscript(videoID string, languages []string) (string, []models.TranscriptSegment, error) {
+	var response *youtube.CaptionListResponse
+	err := ycs.youtubeService.withAPIKey(func(service *youtube.Service) error {
+		var err error
+		response, err = service.Captions.List([]string{"snippet"}, videoID).Do()
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list caption tracks: %w", err)
+	}
+
+	// Track kind per language; prefer manual tracks over auto-generated ones
+	tracks := make(map[string]string)
+	for _, caption := range response.Items {
+		if caption.Snippet == nil {
+			continue
+		}
+		language := caption.Snippet.Language
+		if kind, seen := tracks[language]; !seen || kind == "asr" {
+			tracks[language] = caption.Snippet.TrackKind
+		}
+	}
+
+	for _, language := range languages {
+		kind, ok := tracks[language]
+		if !ok {
+			continue
+		}
+
+		segments, err := ycs.download(videoID, language, kind)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(segments) > 0 {
+			return language, segments, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+func (ycs *YouTubeCaptionSource) download(videoID, language, kind string) ([]models.TranscriptSegment, error) {
+	params := url.Values{}
+	params.Set("v", videoID)
+	params.Set("lang", language)
+	if kind == "asr" {
+		params.Set("kind", "asr")
+	}
+
+	resp, err := ycs.httpClient.Get(ycs.timedTextURL + "?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to download captions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download captions: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 5<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read captions: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return parseCaptions(data, CaptionFormatTimedText)
+}
+
+// FixtureCaptionSource reads caption files from a local directory, named
+// <video_id>.<language>.<xml|vtt|srt>. Useful for development and testing
+// without spending API quota.
+type FixtureCaptionSource struct {
+	dir string
+}
+
+func NewFixtureCaptionSource(dir string) *FixtureCaptionSource {
+	return &FixtureCaptionSource{dir: dir}
+}
+
+func (fcs *FixtureCaptionSource) FetchTranscript(videoID string, languages []string) (string, []models.TranscriptSegment, error) {
+	formats := []string{CaptionFormatTimedText, CaptionFormatWebVTT, CaptionFormatSRT}
+
+	for _, language := range languages {
+		for _, format := range formats {
+			path := filepath.Join(fcs.dir, fmt.Sprintf("%s.%s.%s", videoID, language, format))
+			data, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to read caption fixture: %w", err)
+			}
+
+			segments, err := parseCaptions(data, format)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid caption fixture %s: %w", path, err)
+			}
+			return language, segments, nil
+		}
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// FetchVideoCategories returns the video category catalog of a region (1 quota unit)
+func (ys *YouTubeService) FetchVideoCategories(region string) ([]*models.VideoCategory, error) {
+	var response *youtube.VideoCategoryListResponse
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		var err error
+		response, err = service.VideoCategories.List([]string{"snippet"}).RegionCode(region).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video categories: %w", err)
+	}
+
+	return categoriesFromItems(region, response.Items, time.Now()), nil
+}
+
+// categoriesFromItems maps a region's catalog, skipping items without a snippet
+func categoriesFromItems(region string, items []*youtube.VideoCategory, now time.Time) []*models.VideoCategory {
+	categories := make([]*models.VideoCategory, 0, len(items))
+	for _, item := range items {
+		if item.Snippet == nil {
+			continue
+		}
+		categories = append(categories, &models.VideoCategory{
+			CategoryID: item.Id,
+			Region:     region,
+			Title:      item.Snippet.Title,
+			Assignable: item.Snippet.Assignable,
+			UpdatedAt:  now,
+		})
+	}
+
+	return categories
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestCategoriesFromItems(t *testing.T) {
+	now := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	items := []*youtube.VideoCategory{
+		{Id: "17", Snippet: &youtube.VideoCategorySnippet{Title: "Sports", Assignable: true}},
+		{Id: "18", Snippet: &youtube.VideoCategorySnippet{Title: "Short Movies"}},
+		{Id: "99"}, // Without a snippet
+	}
+
+	want := []*models.VideoCategory{
+		{CategoryID: "17", Region: "IN", Title: "Sports", Assignable: true, UpdatedAt: now},
+		{CategoryID: "18", Region: "IN", Title: "Short Movies", UpdatedAt: now},
+	}
+
+	if got := categoriesFromItems("IN", items, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("categoriesFromItems() =\n  %+v\nwant\n  %+v", got, want)
+	}
+	if got := categoriesFromItems("IN", nil, now); len(got) != 0 {
+		t.Errorf("categoriesFromItems(nil) = %+v, want empty", got)
+	}
+}
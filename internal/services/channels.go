@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// FetchChannels returns channel details, requesting 50 IDs per
+// Channels.List call (1 quota unit each)
+func (ys *YouTubeService) FetchChannels(channelIDs []string) ([]*models.Channel, error) {
+	var channels []*models.Channel
+	for start := 0; start < len(channelIDs); start += videosListBatchSize {
+		end := start + videosListBatchSize
+		if end > len(channelIDs) {
+			end = len(channelIDs)
+		}
+
+		var response *youtube.ChannelListResponse
+		err := ys.withAPIKey(func(service *youtube.Service) error {
+			var err error
+			response, err = service.Channels.List([]string{"snippet", "statistics"}).Id(channelIDs[start:end]...).Do()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch channels: %w", err)
+		}
+
+		for _, item := range response.Items {
+			channels = append(channels, channelFromItem(item))
+		}
+	}
+
+	return channels, nil
+}
+
+func channelFromItem(item *youtube.Channel) *models.Channel {
+	channel := &models.Channel{
+		ChannelID:   item.Id,
+		RefreshedAt: time.Now(),
+	}
+
+	if item.Snippet != nil {
+		channel.Title = item.Snippet.Title
+		channel.Description = item.Snippet.Description
+		channel.CustomURL = item.Snippet.CustomUrl
+		channel.Country = item.Snippet.Country
+		channel.PublishedAt, _ = time.Parse(time.RFC3339, item.Snippet.PublishedAt)
+		if item.Snippet.Thumbnails != nil {
+			channel.AvatarURL = getThumbnailURL(item.Snippet.Thumbnails.High)
+			if channel.AvatarURL == "" {
+				channel.AvatarURL = getThumbnailURL(item.Snippet.Thumbnails.Default)
+			}
+		}
+	}
+
+	if item.Statistics != nil {
+		channel.SubscriberCount = int64(item.Statistics.SubscriberCount)
+		channel.HiddenSubscriberCount = item.Statistics.HiddenSubscriberCount
+		channel.VideoCount = int64(item.Statistics.VideoCount)
+		channel.ViewCount = int64(item.Statistics.ViewCount)
+	}
+
+	return channel
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestChannelFromItem(t *testing.T) {
+	tests := []struct {
+		name string
+		item *youtube.Channel
+		want *models.Channel
+	}{
+		{
+			name: "snippet and statistics",
+			item: &youtube.Channel{
+				Id: "UC1",
+				Snippet: &youtube.ChannelSnippet{
+					Title:       "Cricket Daily",
+					Description: "Highlights every day",
+					CustomUrl:   "@cricketdaily",
+					Country:     "IN",
+					PublishedAt: "2015-03-01T08:00:00Z",
+					Thumbnails: &youtube.ThumbnailDetails{
+						Default: &youtube.Thumbnail{Url: "https://yt3.example/default.jpg"},
+						High:    &youtube.Thumbnail{Url: "https://yt3.example/high.jpg"},
+					},
+				},
+				Statistics: &youtube.ChannelStatistics{
+					SubscriberCount: 1500000,
+					VideoCount:      4200,
+					ViewCount:       900000000,
+				},
+			},
+			want: &models.Channel{
+				ChannelID:       "UC1",
+				Title:           "Cricket Daily",
+				Description:     "Highlights every day",
+				CustomURL:       "@cricketdaily",
+				Country:         "IN",
+				PublishedAt:     time.Date(2015, 3, 1, 8, 0, 0, 0, time.UTC),
+				AvatarURL:       "https://yt3.example/high.jpg",
+				SubscriberCount: 1500000,
+				VideoCount:      4200,
+				ViewCount:       900000000,
+			},
+		},
+		{
+			name: "default avatar and hidden subscribers",
+			item: &youtube.Channel{
+				Id: "UC2",
+				Snippet: &youtube.ChannelSnippet{
+					Title:      "Quiet Channel",
+					Thumbnails: &youtube.ThumbnailDetails{Default: &youtube.Thumbnail{Url: "https://yt3.example/default.jpg"}},
+				},
+				Statistics: &youtube.ChannelStatistics{HiddenSubscriberCount: true, VideoCount: 3},
+			},
+			want: &models.Channel{
+				ChannelID:             "UC2",
+				Title:                 "Quiet Channel",
+				AvatarURL:             "https://yt3.example/default.jpg",
+				HiddenSubscriberCount: true,
+				VideoCount:            3,
+			},
+		},
+		{
+			name: "no parts",
+			item: &youtube.Channel{Id: "UC3"},
+			want: &models.Channel{ChannelID: "UC3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := channelFromItem(tt.item)
+			if got.RefreshedAt.IsZero() {
+				t.Error("RefreshedAt not set")
+			}
+			got.RefreshedAt = time.Time{}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("channelFromItem() =\n  %+v\nwant\n  %+v", got, tt.want)
+			}
+		})
+	}
+}
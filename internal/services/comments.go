@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// CommentThreads.List returns at most 100 threads per page
+const maxCommentThreadsPerPage = 100
+
+// FetchTopComments returns up to max top-level comments of a video in
+// relevance order. Videos with comments disabled return no comments.
+func (ys *YouTubeService) FetchTopComments(videoID string, max int) ([]*models.Comment, error) {
+	if max > maxCommentThreadsPerPage {
+		max = maxCommentThreadsPerPage
+	}
+
+	var response *youtube.CommentThreadListResponse
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		var err error
+		response, err = service.CommentThreads.List([]string{"snippet"}).
+			VideoId(videoID).
+			Order("relevance").
+			TextFormat("plainText").
+			MaxResults(int64(max)).
+			Do()
+		return err
+	})
+	if err != nil {
+		if isCommentsDisabled(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	return commentsFromThreads(videoID, response.Items), nil
+}
+
+// commentsFromThreads maps comment threads to their top-level comments,
+// ranked in the order returned
+func commentsFromThreads(videoID string, threads []*youtube.CommentThread) []*models.Comment {
+	var comments []*models.Comment
+	for rank, thread := range threads {
+		if thread.Snippet == nil || thread.Snippet.TopLevelComment == nil || thread.Snippet.TopLevelComment.Snippet == nil {
+			continue
+		}
+		snippet := thread.Snippet.TopLevelComment.Snippet
+
+		publishedAt, _ := time.Parse(time.RFC3339, snippet.PublishedAt)
+		editedAt, _ := time.Parse(time.RFC3339, snippet.UpdatedAt)
+
+		comment := &models.Comment{
+			CommentID:       thread.Id,
+			VideoID:         videoID,
+			AuthorName:      snippet.AuthorDisplayName,
+			AuthorAvatarURL: snippet.AuthorProfileImageUrl,
+			Text:            snippet.TextDisplay,
+			LikeCount:       snippet.LikeCount,
+			ReplyCount:      thread.Snippet.TotalReplyCount,
+			Rank:            rank + 1,
+			PublishedAt:     publishedAt,
+			EditedAt:        editedAt,
+		}
+		if snippet.AuthorChannelId != nil {
+			comment.AuthorChannelID = snippet.AuthorChannelId.Value
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments
+}
+
+func isCommentsDisabled(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "commentsDisabled" {
+			return true
+		}
+	}
+	return false
+}
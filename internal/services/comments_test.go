@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestCommentsFromThreads(t *testing.T) {
+	published := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	edited := published.Add(time.Hour)
+
+	threads := []*youtube.CommentThread{
+		{
+			Id: "thread1",
+			Snippet: &youtube.CommentThreadSnippet{
+				TotalReplyCount: 4,
+				TopLevelComment: &youtube.Comment{Snippet: &youtube.CommentSnippet{
+					AuthorDisplayName:     "Fan",
+					AuthorProfileImageUrl: "https://yt3.example/fan.jpg",
+					AuthorChannelId:       &youtube.CommentSnippetAuthorChannelId{Value: "UCfan"},
+					TextDisplay:           "What a catch!",
+					LikeCount:             120,
+					PublishedAt:           "2026-01-14T10:00:00Z",
+					UpdatedAt:             "2026-01-14T11:00:00Z",
+				}},
+			},
+		},
+		{
+			// Without an author channel or a valid edit time
+			Id: "thread2",
+			Snippet: &youtube.CommentThreadSnippet{
+				TopLevelComment: &youtube.Comment{Snippet: &youtube.CommentSnippet{
+					AuthorDisplayName: "Guest",
+					TextDisplay:       "First",
+					PublishedAt:       "2026-01-14T10:00:00Z",
+					UpdatedAt:         "yesterday",
+				}},
+			},
+		},
+		// Threads without a top-level comment are skipped
+		{Id: "thread3", Snippet: &youtube.CommentThreadSnippet{}},
+		{Id: "thread4"},
+	}
+
+	want := []*models.Comment{
+		{
+			CommentID:       "thread1",
+			VideoID:         "abc",
+			AuthorName:      "Fan",
+			AuthorChannelID: "UCfan",
+			AuthorAvatarURL: "https://yt3.example/fan.jpg",
+			Text:            "What a catch!",
+			LikeCount:       120,
+			ReplyCount:      4,
+			Rank:            1,
+			PublishedAt:     published,
+			EditedAt:        edited,
+		},
+		{
+			CommentID:   "thread2",
+			VideoID:     "abc",
+			AuthorName:  "Guest",
+			Text:        "First",
+			Rank:        2,
+			PublishedAt: published,
+		},
+	}
+
+	if got := commentsFromThreads("abc", threads); !reflect.DeepEqual(got, want) {
+		t.Errorf("commentsFromThreads() =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
+
+func TestIsCommentsDisabled(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("failed to fetch: %w", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "commentsDisabled"}}}), true},
+		{&googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}, false},
+		{fmt.Errorf("connection reset by peer"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isCommentsDisabled(tt.err); got != tt.want {
+			t.Errorf("isCommentsDisabled(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
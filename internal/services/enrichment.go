@@ -0,0 +1,229 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// YouTube allows up to 50 IDs per Videos.List call
+const videosListBatchSize = 50
+
+// Parts requested from Videos.List when enriching search results
+var enrichmentParts = []string{"snippet", "contentDetails", "status", "player", "liveStreamingDetails"}
+
+// enrichVideos fills in details that search results don't carry
+// (made for kids, content rating, duration, format, category). Costs 1 quota
+// unit per 50 videos.
+func (ys *YouTubeService) enrichVideos(videos []*models.Video) error {
+	byID := make(map[string]*models.Video, len(videos))
+	var ids []string
+	for _, video := range videos {
+		if video.VideoID == "" {
+			continue
+		}
+		if _, seen := byID[video.VideoID]; !seen {
+			ids = append(ids, video.VideoID)
+		}
+		byID[video.VideoID] = video
+	}
+
+	var items []*youtube.Video
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		var err error
+		items, err = listVideos(service, ids, enrichmentParts)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if video, ok := byID[item.Id]; ok {
+			applyVideoDetails(video, item)
+		}
+	}
+
+	return nil
+}
+
+// listVideos calls Videos.List in batches of 50 IDs
+func listVideos(service *youtube.Service, ids []string, parts []string) ([]*youtube.Video, error) {
+	var items []*youtube.Video
+
+	for start := 0; start < len(ids); start += videosListBatchSize {
+		end := start + videosListBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		response, err := service.Videos.List(parts).Id(ids[start:end]...).MaxWidth(playerMaxWidth).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch video details: %w", err)
+		}
+		items = append(items, response.Items...)
+	}
+
+	return items, nil
+}
+
+// Parts requested when re-checking tracked broadcasts. The duration and
+// player size classify the recording once a broadcast has ended.
+var broadcastParts = []string{"liveStreamingDetails", "contentDetails", "player"}
+
+// BroadcastUpdate is the latest state of a tracked broadcast. Once it has
+// completed, Format and DurationSeconds describe the recording.
+type BroadcastUpdate struct {
+	Broadcast       *models.Broadcast
+	Format          string
+	DurationSeconds int64
+}
+
+// FetchBroadcasts returns the current broadcast details of live and
+// upcoming videos. Videos missing from the result were deleted or made
+// private.
+func (ys *YouTubeService) FetchBroadcasts(videos []models.Video) (map[string]*BroadcastUpdate, error) {
+	byID := make(map[string]models.Video, len(videos))
+	ids := make([]string, 0, len(videos))
+	for _, video := range videos {
+		byID[video.VideoID] = video
+		ids = append(ids, video.VideoID)
+	}
+
+	var items []*youtube.Video
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		var err error
+		items, err = listVideos(service, ids, broadcastParts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]*BroadcastUpdate, len(items))
+	for _, item := range items {
+		if update := broadcastUpdate(byID[item.Id], item); update != nil {
+			updates[item.Id] = update
+		}
+	}
+
+	return updates, nil
+}
+
+// broadcastUpdate derives a tracked video's broadcast state from a fresh
+// Videos.List item. A completed broadcast is reclassified from its final
+// duration, so a finished stream stops counting as live.
+func broadcastUpdate(video models.Video, item *youtube.Video) *BroadcastUpdate {
+	broadcast := broadcastFromDetails(item.LiveStreamingDetails)
+	if broadcast == nil {
+		return nil
+	}
+
+	update := &BroadcastUpdate{Broadcast: broadcast, Format: video.Format, DurationSeconds: video.DurationSeconds}
+	if broadcast.State != models.BroadcastCompleted {
+		return update
+	}
+
+	video.LiveBroadcastContent = broadcast.LiveBroadcastContent()
+	if item.ContentDetails != nil {
+		if seconds, ok := parseISODuration(item.ContentDetails.Duration); ok {
+			video.DurationSeconds = seconds
+		}
+	}
+	update.Format = classifyFormat(&video, item)
+	update.DurationSeconds = video.DurationSeconds
+
+	return update
+}
+
+// broadcastFromDetails derives the broadcast state from liveStreamingDetails
+func broadcastFromDetails(details *youtube.VideoLiveStreamingDetails) *models.Broadcast {
+	if details == nil {
+		return nil
+	}
+
+	broadcast := &models.Broadcast{
+		ScheduledStart:    parseOptionalTime(details.ScheduledStartTime),
+		ScheduledEnd:      parseOptionalTime(details.ScheduledEndTime),
+		ActualStart:       parseOptionalTime(details.ActualStartTime),
+		ActualEnd:         parseOptionalTime(details.ActualEndTime),
+		ConcurrentViewers: int64(details.ConcurrentViewers),
+		CheckedAt:         time.Now(),
+	}
+
+	switch {
+	case broadcast.ActualEnd != nil:
+		broadcast.State = models.BroadcastCompleted
+	case broadcast.ActualStart != nil:
+		broadcast.State = models.BroadcastLive
+	default:
+		broadcast.State = models.BroadcastUpcoming
+	}
+
+	return broadcast
+}
+
+func parseOptionalTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func applyVideoDetails(video *models.Video, item *youtube.Video) {
+	video.Enriched = true
+
+	if item.Snippet != nil {
+		video.CategoryID = item.Snippet.CategoryId
+		video.AudioLanguage = item.Snippet.DefaultAudioLanguage
+		if video.AudioLanguage == "" {
+			video.AudioLanguage = item.Snippet.DefaultLanguage
+		}
+	}
+
+	if item.Status != nil {
+		video.MadeForKids = item.Status.MadeForKids || item.Status.SelfDeclaredMadeForKids
+	}
+
+	if item.ContentDetails != nil && item.ContentDetails.ContentRating != nil {
+		video.ContentRating = item.ContentDetails.ContentRating.YtRating
+		video.AgeRestricted = video.ContentRating == "ytAgeRestricted"
+	}
+
+	if item.ContentDetails != nil {
+		if seconds, ok := parseISODuration(item.ContentDetails.Duration); ok {
+			video.DurationSeconds = seconds
+		}
+	}
+
+	video.Format = classifyFormat(video, item)
+	video.Broadcast = broadcastFromDetails(item.LiveStreamingDetails)
+}
+
+// validSafeSearch reports whether level is accepted by the YouTube search API
+func validSafeSearch(level string) bool {
+	switch level {
+	case "none", "moderate", "strict":
+		return true
+	}
+	return false
+}
+
+// safeSearchFor returns the safe-search level configured for a query
+func (ys *YouTubeService) safeSearchFor(query string) string {
+	if level, ok := ys.querySafeSearch[query]; ok {
+		if validSafeSearch(level) {
+			return level
+		}
+		log.Printf("⚠️ Invalid safe-search level %q for query '%s', using '%s'", level, query, ys.safeSearch)
+	}
+	return ys.safeSearch
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestBroadcastFromDetails(t *testing.T) {
+	start := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	tests := []struct {
+		name        string
+		details     *youtube.VideoLiveStreamingDetails
+		wantState   string
+		wantStart   *time.Time
+		wantEnd     *time.Time
+		wantViewers int64
+	}{
+		{
+			name:      "upcoming",
+			details:   &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-14T10:00:00Z"},
+			wantState: models.BroadcastUpcoming,
+		},
+		{
+			name:        "live",
+			details:     &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-14T09:55:00Z", ActualStartTime: "2026-01-14T10:00:00Z", ConcurrentViewers: 1200},
+			wantState:   models.BroadcastLive,
+			wantStart:   &start,
+			wantViewers: 1200,
+		},
+		{
+			name:      "completed",
+			details:   &youtube.VideoLiveStreamingDetails{ActualStartTime: "2026-01-14T10:00:00Z", ActualEndTime: "2026-01-14T12:00:00Z"},
+			wantState: models.BroadcastCompleted,
+			wantStart: &start,
+			wantEnd:   &end,
+		},
+		{
+			// Unparseable times are dropped rather than failing the check
+			name:      "invalid start time",
+			details:   &youtube.VideoLiveStreamingDetails{ActualStartTime: "soon"},
+			wantState: models.BroadcastUpcoming,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broadcast := broadcastFromDetails(tt.details)
+			if broadcast.State != tt.wantState {
+				t.Errorf("State = %q, want %q", broadcast.State, tt.wantState)
+			}
+			if !sameTime(broadcast.ActualStart, tt.wantStart) || !sameTime(broadcast.ActualEnd, tt.wantEnd) {
+				t.Errorf("actual start/end = %v/%v, want %v/%v", broadcast.ActualStart, broadcast.ActualEnd, tt.wantStart, tt.wantEnd)
+			}
+			if broadcast.ConcurrentViewers != tt.wantViewers {
+				t.Errorf("ConcurrentViewers = %d, want %d", broadcast.ConcurrentViewers, tt.wantViewers)
+			}
+		})
+	}
+
+	if broadcast := broadcastFromDetails(nil); broadcast != nil {
+		t.Errorf("broadcastFromDetails(nil) = %+v, want nil", broadcast)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestBroadcastUpdateTransitions(t *testing.T) {
+	stream := models.Video{VideoID: "stream", Title: "Match day live", LiveBroadcastContent: "upcoming", Format: models.FormatLive}
+	premiere := models.Video{VideoID: "premiere", Title: "Official trailer", LiveBroadcastContent: "upcoming", Format: models.FormatPremiere, DurationSeconds: 150}
+
+	upcoming := &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-14T10:00:00Z"}
+	live := &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-14T10:00:00Z", ActualStartTime: "2026-01-14T10:01:00Z"}
+	ended := &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-14T10:00:00Z", ActualStartTime: "2026-01-14T10:01:00Z", ActualEndTime: "2026-01-14T12:01:00Z"}
+
+	tests := []struct {
+		name         string
+		video        models.Video
+		details      *youtube.VideoLiveStreamingDetails
+		duration     string
+		vertical     bool
+		wantState    string
+		wantFormat   string
+		wantDuration int64
+	}{
+		{name: "stream still upcoming", video: stream, details: upcoming, duration: "P0D", wantState: models.BroadcastUpcoming, wantFormat: models.FormatLive},
+		{name: "stream goes live", video: stream, details: live, duration: "P0D", wantState: models.BroadcastLive, wantFormat: models.FormatLive},
+		// The recording of an ended stream is a regular upload
+		{name: "stream completes", video: stream, details: ended, duration: "PT2H", wantState: models.BroadcastCompleted, wantFormat: models.FormatStandard, wantDuration: 7200},
+		{name: "vertical stream completes", video: stream, details: ended, duration: "PT55S", vertical: true, wantState: models.BroadcastCompleted, wantFormat: models.FormatShort, wantDuration: 55},
+		// Until the recording is processed there is no duration to classify by
+		{name: "stream completes without duration", video: stream, details: ended, wantState: models.BroadcastCompleted, wantFormat: ""},
+		{name: "premiere plays", video: premiere, details: live, duration: "PT2M30S", wantState: models.BroadcastLive, wantFormat: models.FormatPremiere, wantDuration: 150},
+		{name: "premiere completes", video: premiere, details: ended, duration: "PT2M30S", wantState: models.BroadcastCompleted, wantFormat: models.FormatStandard, wantDuration: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &youtube.Video{Id: tt.video.VideoID, LiveStreamingDetails: tt.details}
+			if tt.duration != "" {
+				item.ContentDetails = &youtube.VideoContentDetails{Duration: tt.duration}
+			}
+			if tt.vertical {
+				item.Player = &youtube.VideoPlayer{EmbedWidth: 360, EmbedHeight: 640}
+			}
+
+			update := broadcastUpdate(tt.video, item)
+			if update.Broadcast.State != tt.wantState {
+				t.Errorf("State = %q, want %q", update.Broadcast.State, tt.wantState)
+			}
+			if update.Format != tt.wantFormat || update.DurationSeconds != tt.wantDuration {
+				t.Errorf("format = %q (%ds), want %q (%ds)", update.Format, update.DurationSeconds, tt.wantFormat, tt.wantDuration)
+			}
+		})
+	}
+
+	if update := broadcastUpdate(stream, &youtube.Video{Id: "stream"}); update != nil {
+		t.Errorf("broadcastUpdate() without liveStreamingDetails = %+v, want nil", update)
+	}
+}
+
+func TestApplyVideoDetails(t *testing.T) {
+	video := &models.Video{VideoID: "abc", Title: "Horror movie trailer"}
+	applyVideoDetails(video, &youtube.Video{
+		Id:             "abc",
+		Snippet:        &youtube.VideoSnippet{CategoryId: "1", DefaultLanguage: "en"},
+		ContentDetails: &youtube.VideoContentDetails{Duration: "PT2M30S", ContentRating: &youtube.ContentRating{YtRating: "ytAgeRestricted"}},
+	})
+
+	if !video.Enriched || !video.AgeRestricted || video.ContentRating != "ytAgeRestricted" {
+		t.Errorf("enriched = %v, age restricted = %v, rating = %q; want an enriched, age-restricted video", video.Enriched, video.AgeRestricted, video.ContentRating)
+	}
+	if video.CategoryID != "1" || video.AudioLanguage != "en" || video.DurationSeconds != 150 || video.Format != models.FormatStandard {
+		t.Errorf("got category %q, language %q, %ds %q", video.CategoryID, video.AudioLanguage, video.DurationSeconds, video.Format)
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/search"
+)
+
+// Rejection describes which filter rule dropped a video and why
+type Rejection struct {
+	Rule   models.FilterRule
+	Reason string
+}
+
+type compiledRule struct {
+	rule     models.FilterRule
+	values   []string // Channel IDs (case-sensitive), or keywords as written
+	phrases  []string // Keywords as space-delimited words, see keywordPhrase
+	patterns []*regexp.Regexp
+}
+
+// FilterEngine evaluates ingestion filter rules before videos are stored
+type FilterEngine struct {
+	ruleRepo *repository.FilterRuleRepository
+	mutex    sync.RWMutex
+	rules    []compiledRule
+}
+
+func NewFilterEngine(ruleRepo *repository.FilterRuleRepository) *FilterEngine {
+	return &FilterEngine{
+		ruleRepo: ruleRepo,
+	}
+}
+
+// ValidateFilterRule checks a rule before it is saved through the admin API
+func ValidateFilterRule(rule *models.FilterRule) error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch rule.Type {
+	case models.RuleTypeChannelAllow, models.RuleTypeChannelBlock,
+		models.RuleTypeIncludeKeyword, models.RuleTypeExcludeKeyword:
+	case models.RuleTypeIncludeRegex, models.RuleTypeExcludeRegex:
+		for _, pattern := range rule.Values {
+			if _, err := compileRulePattern(pattern); err != nil {
+				return fmt.Errorf("invalid regex %q: %v", pattern, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown rule type %q", rule.Type)
+	}
+
+	for _, field := range rule.Fields {
+		if field != models.RuleFieldTitle && field != models.RuleFieldDescription {
+			return fmt.Errorf("unknown field %q (expected title or description)", field)
+		}
+	}
+
+	if len(rule.Values) == 0 {
+		return fmt.Errorf("at least one value is required")
+	}
+	for _, value := range rule.Values {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("values cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// Reload fetches the enabled rules from the database
+func (fe *FilterEngine) Reload() error {
+	rules, err := fe.ruleRepo.ListEnabled()
+	if err != nil {
+		return err
+	}
+
+	compiled := compileRules(rules)
+
+	fe.mutex.Lock()
+	fe.rules = compiled
+	fe.mutex.Unlock()
+
+	return nil
+}
+
+// compileRules prepares rules for Evaluate, skipping invalid regexes
+func compileRules(rules []models.FilterRule) []compiledRule {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		cr := compiledRule{rule: rule}
+		switch rule.Type {
+		case models.RuleTypeIncludeRegex, models.RuleTypeExcludeRegex:
+			for _, pattern := range rule.Values {
+				re, err := compileRulePattern(pattern)
+				if err != nil {
+					log.Printf("⚠️ Skipping invalid regex %q in filter rule '%s': %v", pattern, rule.Name, err)
+					continue
+				}
+				cr.patterns = append(cr.patterns, re)
+			}
+		case models.RuleTypeIncludeKeyword, models.RuleTypeExcludeKeyword:
+			for _, value := range rule.Values {
+				if phrase := keywordPhrase(value); phrase != "" {
+					cr.values = append(cr.values, strings.TrimSpace(value))
+					cr.phrases = append(cr.phrases, phrase)
+				}
+			}
+		default:
+			for _, value := range rule.Values {
+				cr.values = append(cr.values, strings.TrimSpace(value))
+			}
+		}
+		compiled = append(compiled, cr)
+	}
+
+	return compiled
+}
+
+// RecordRejection logs which rule dropped a video
+func (fe *FilterEngine) RecordRejection(rejection *models.RejectedVideo) error {
+	return fe.ruleRepo.RecordRejection(rejection)
+}
+
+// Evaluate returns the rule that rejects the video, or nil if it may be stored.
+// Only global rules and rules scoped to the video's search query apply.
+func (fe *FilterEngine) Evaluate(video *models.Video) *Rejection {
+	fe.mutex.RLock()
+	defer fe.mutex.RUnlock()
+
+	channelID := video.ChannelID
+	var allowRules []compiledRule
+
+	for _, cr := range fe.rules {
+		if cr.rule.Query != "" && cr.rule.Query != video.SearchQuery {
+			continue
+		}
+
+		switch cr.rule.Type {
+		case models.RuleTypeChannelAllow:
+			allowRules = append(allowRules, cr)
+
+		case models.RuleTypeChannelBlock:
+			if containsString(cr.values, channelID) {
+				return &Rejection{Rule: cr.rule, Reason: fmt.Sprintf("channel %s is blocked", video.ChannelID)}
+			}
+
+		case models.RuleTypeExcludeKeyword:
+			if field, keyword, ok := cr.matchKeyword(video); ok {
+				return &Rejection{Rule: cr.rule, Reason: fmt.Sprintf("%s contains excluded keyword %q", field, keyword)}
+			}
+
+		case models.RuleTypeIncludeKeyword:
+			if _, _, ok := cr.matchKeyword(video); !ok {
+				return &Rejection{Rule: cr.rule, Reason: "none of the required keywords found"}
+			}
+
+		case models.RuleTypeExcludeRegex:
+			if field, pattern, ok := cr.matchRegex(video); ok {
+				return &Rejection{Rule: cr.rule, Reason: fmt.Sprintf("%s matches excluded pattern %q", field, pattern)}
+			}
+
+		case models.RuleTypeIncludeRegex:
+			if _, _, ok := cr.matchRegex(video); !ok {
+				return &Rejection{Rule: cr.rule, Reason: "none of the required patterns matched"}
+			}
+		}
+	}
+
+	// With allow lists in scope, the channel must appear in at least one of them
+	if len(allowRules) > 0 {
+		for _, cr := range allowRules {
+			if containsString(cr.values, channelID) {
+				return nil
+			}
+		}
+		return &Rejection{Rule: allowRules[0].rule, Reason: fmt.Sprintf("channel %s is not in the allow list", video.ChannelID)}
+	}
+
+	return nil
+}
+
+type fieldText struct {
+	field string
+	text  string
+}
+
+func (cr compiledRule) fields(video *models.Video) []fieldText {
+	fields := cr.rule.Fields
+	if len(fields) == 0 {
+		fields = []string{models.RuleFieldTitle, models.RuleFieldDescription}
+	}
+
+	texts := make([]fieldText, 0, len(fields))
+	for _, field := range fields {
+		switch field {
+		case models.RuleFieldTitle:
+			texts = append(texts, fieldText{field: field, text: video.Title})
+		case models.RuleFieldDescription:
+			texts = append(texts, fieldText{field: field, text: video.Description})
+		}
+	}
+	return texts
+}
+
+// matchKeyword matches whole words, so "bet" rejects "bet now" but not
+// "better"; a multi-word keyword must appear as consecutive words
+func (cr compiledRule) matchKeyword(video *models.Video) (string, string, bool) {
+	for _, ft := range cr.fields(video) {
+		text := keywordPhrase(ft.text)
+		for i, phrase := range cr.phrases {
+			if strings.Contains(text, phrase) {
+				return ft.field, cr.values[i], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// keywordPhrase normalizes text into space-delimited words (with a space
+// at each end), so substring checks between phrases match whole words
+func keywordPhrase(text string) string {
+	words := search.Words(text)
+	if len(words) == 0 {
+		return ""
+	}
+	return " " + strings.Join(words, " ") + " "
+}
+
+// compileRulePattern compiles a regex rule value. Patterns match
+// case-insensitively, like keywords.
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func (cr compiledRule) matchRegex(video *models.Video) (string, string, bool) {
+	for _, ft := range cr.fields(video) {
+		for _, re := range cr.patterns {
+			if re.MatchString(ft.text) {
+				return ft.field, strings.TrimPrefix(re.String(), "(?i)"), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"testing"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestFilterEngineEvaluate(t *testing.T) {
+	video := func(channelID, title string) *models.Video {
+		return &models.Video{VideoID: "abc", ChannelID: channelID, Title: title, SearchQuery: "cricket"}
+	}
+
+	tests := []struct {
+		name     string
+		rules    []models.FilterRule
+		video    *models.Video
+		wantRule string // Name of the rejecting rule, empty if the video is kept
+	}{
+		{
+			name:     "excluded keyword",
+			rules:    []models.FilterRule{{Name: "betting", Type: models.RuleTypeExcludeKeyword, Values: []string{"bet"}}},
+			video:    video("UCa", "Place your BET now"),
+			wantRule: "betting",
+		},
+		{
+			// Keywords match whole words only
+			name:  "keyword inside a word",
+			rules: []models.FilterRule{{Name: "betting", Type: models.RuleTypeExcludeKeyword, Values: []string{"bet"}}},
+			video: video("UCa", "Better batting tips"),
+		},
+		{
+			name:     "multi-word keyword",
+			rules:    []models.FilterRule{{Name: "spoilers", Type: models.RuleTypeExcludeKeyword, Values: []string{"full match"}}},
+			video:    video("UCa", "India vs Australia: Full-Match replay"),
+			wantRule: "spoilers",
+		},
+		{
+			name:     "missing required keyword",
+			rules:    []models.FilterRule{{Name: "highlights only", Type: models.RuleTypeIncludeKeyword, Values: []string{"highlights"}}},
+			video:    video("UCa", "Press conference"),
+			wantRule: "highlights only",
+		},
+		{
+			name:  "required keyword in description",
+			rules: []models.FilterRule{{Name: "highlights only", Type: models.RuleTypeIncludeKeyword, Values: []string{"highlights"}}},
+			video: &models.Video{ChannelID: "UCa", Title: "Day 3", Description: "Extended highlights"},
+		},
+		{
+			name:  "keyword outside the rule's fields",
+			rules: []models.FilterRule{{Name: "betting", Type: models.RuleTypeExcludeKeyword, Fields: []string{models.RuleFieldTitle}, Values: []string{"bet"}}},
+			video: &models.Video{ChannelID: "UCa", Title: "Day 3", Description: "bet now"},
+		},
+		{
+			name:     "excluded regex",
+			rules:    []models.FilterRule{{Name: "shorts", Type: models.RuleTypeExcludeRegex, Values: []string{`#shorts?\b`}}},
+			video:    video("UCa", "Last ball six #SHORTS"),
+			wantRule: "shorts",
+		},
+		{
+			name:     "required regex",
+			rules:    []models.FilterRule{{Name: "scores", Type: models.RuleTypeIncludeRegex, Values: []string{`\d+/\d+`}}},
+			video:    video("UCa", "Match recap"),
+			wantRule: "scores",
+		},
+		{
+			// Invalid patterns are skipped when rules are compiled
+			name:  "invalid regex",
+			rules: []models.FilterRule{{Name: "broken", Type: models.RuleTypeExcludeRegex, Values: []string{"("}}},
+			video: video("UCa", "Match recap"),
+		},
+		{
+			name:     "blocked channel",
+			rules:    []models.FilterRule{{Name: "spam", Type: models.RuleTypeChannelBlock, Values: []string{" UCspam "}}},
+			video:    video("UCspam", "Match recap"),
+			wantRule: "spam",
+		},
+		{
+			// Channel IDs are case-sensitive
+			name:  "blocked channel with different case",
+			rules: []models.FilterRule{{Name: "spam", Type: models.RuleTypeChannelBlock, Values: []string{"UCspam"}}},
+			video: video("UCSPAM", "Match recap"),
+		},
+		{
+			name: "channel in an allow list",
+			rules: []models.FilterRule{
+				{Name: "official", Type: models.RuleTypeChannelAllow, Values: []string{"UCicc"}},
+				{Name: "broadcasters", Type: models.RuleTypeChannelAllow, Values: []string{"UCstar"}},
+			},
+			video: video("UCstar", "Match recap"),
+		},
+		{
+			name: "channel missing from the allow lists",
+			rules: []models.FilterRule{
+				{Name: "official", Type: models.RuleTypeChannelAllow, Values: []string{"UCicc"}},
+				{Name: "broadcasters", Type: models.RuleTypeChannelAllow, Values: []string{"UCstar"}},
+			},
+			video:    video("UCicc2", "Match recap"),
+			wantRule: "official",
+		},
+		{
+			name:     "allow list is case-sensitive",
+			rules:    []models.FilterRule{{Name: "official", Type: models.RuleTypeChannelAllow, Values: []string{"UCicc"}}},
+			video:    video("UCICC", "Match recap"),
+			wantRule: "official",
+		},
+		{
+			// Rejecting rules apply even to allowed channels
+			name: "block beats allow",
+			rules: []models.FilterRule{
+				{Name: "official", Type: models.RuleTypeChannelAllow, Values: []string{"UCicc"}},
+				{Name: "betting", Type: models.RuleTypeExcludeKeyword, Values: []string{"bet"}},
+			},
+			video:    video("UCicc", "bet on the final"),
+			wantRule: "betting",
+		},
+		{
+			// The first rejecting rule, in rule order, is reported
+			name: "first rejecting rule wins",
+			rules: []models.FilterRule{
+				{Name: "spam", Type: models.RuleTypeChannelBlock, Values: []string{"UCspam"}},
+				{Name: "betting", Type: models.RuleTypeExcludeKeyword, Values: []string{"bet"}},
+			},
+			video:    video("UCspam", "bet on the final"),
+			wantRule: "spam",
+		},
+		{
+			name: "rule scoped to another query",
+			rules: []models.FilterRule{
+				{Name: "football betting", Type: models.RuleTypeExcludeKeyword, Query: "football", Values: []string{"bet"}},
+			},
+			video: video("UCa", "bet on the final"),
+		},
+		{
+			name: "rule scoped to the video's query",
+			rules: []models.FilterRule{
+				{Name: "cricket betting", Type: models.RuleTypeExcludeKeyword, Query: "cricket", Values: []string{"bet"}},
+			},
+			video:    video("UCa", "bet on the final"),
+			wantRule: "cricket betting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &FilterEngine{rules: compileRules(tt.rules)}
+			rejection := engine.Evaluate(tt.video)
+
+			var got string
+			if rejection != nil {
+				got = rejection.Rule.Name
+			}
+			if got != tt.wantRule {
+				t.Errorf("Evaluate() rejected by %q, want %q (%+v)", got, tt.wantRule, rejection)
+			}
+		})
+	}
+}
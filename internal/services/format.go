@@ -0,0 +1,93 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// Shorts are at most 3 minutes; up to a minute no other hint is needed
+const (
+	shortMaxSeconds        = 180
+	shortCertainMaxSeconds = 60
+)
+
+// Embed width requested from the player part, used to infer aspect ratio
+const playerMaxWidth = 480
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration converts a YouTube duration such as "PT4M13S" to seconds
+func parseISODuration(value string) (int64, bool) {
+	matches := isoDurationPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, false
+	}
+
+	multipliers := []int64{7 * 24 * 3600, 24 * 3600, 3600, 60, 1}
+	var seconds int64
+	for i, multiplier := range multipliers {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(matches[i+1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds += n * multiplier
+	}
+	return seconds, true
+}
+
+// classifyFormat decides whether a video is a short, a regular upload,
+// a live broadcast or a premiere. Premieres are scheduled like live
+// streams but already have a duration because the video was uploaded.
+// Once a broadcast has ended its recording is a regular short or upload.
+func classifyFormat(video *models.Video, item *youtube.Video) string {
+	var hasDuration bool
+	if item.ContentDetails != nil {
+		_, hasDuration = parseISODuration(item.ContentDetails.Duration)
+	}
+
+	switch video.LiveBroadcastContent {
+	case "live":
+		return models.FormatLive
+	case "upcoming":
+		if video.DurationSeconds > 0 {
+			return models.FormatPremiere
+		}
+		return models.FormatLive
+	}
+
+	// Ended streams and completed premieres are classified by duration
+	// like any other upload
+	if item.LiveStreamingDetails != nil && item.LiveStreamingDetails.ActualEndTime == "" {
+		return models.FormatLive
+	}
+
+	if !hasDuration || video.DurationSeconds == 0 {
+		return ""
+	}
+
+	if video.DurationSeconds <= shortCertainMaxSeconds {
+		return models.FormatShort
+	}
+	if video.DurationSeconds <= shortMaxSeconds && (isVertical(item) || hasShortsHint(video)) {
+		return models.FormatShort
+	}
+
+	return models.FormatStandard
+}
+
+func isVertical(item *youtube.Video) bool {
+	return item.Player != nil && item.Player.EmbedWidth > 0 && item.Player.EmbedHeight > item.Player.EmbedWidth
+}
+
+func hasShortsHint(video *models.Video) bool {
+	text := strings.ToLower(video.Title + " " + video.Description)
+	return strings.Contains(text, "#shorts") || strings.Contains(text, "#short ")
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"testing"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   int64
+		wantOK bool
+	}{
+		{"PT4M13S", 253, true},
+		{"PT1H", 3600, true},
+		{"PT1H2M3S", 3723, true},
+		{"PT45S", 45, true},
+		{"P1DT2H", 93600, true},
+		{"P1W", 604800, true},
+		{"P0D", 0, true}, // Live streams in progress
+		{"", 0, false},
+		{"4:13", 0, false},
+		{"PT4.5S", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseISODuration(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseISODuration(%q) = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestClassifyFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		duration  string
+		broadcast string // snippet liveBroadcastContent
+		title     string
+		vertical  bool
+		live      *youtube.VideoLiveStreamingDetails
+		want      string
+	}{
+		{name: "short under a minute", duration: "PT45S", want: models.FormatShort},
+		{name: "vertical under three minutes", duration: "PT2M30S", vertical: true, want: models.FormatShort},
+		{name: "shorts hashtag under three minutes", duration: "PT2M", title: "Last over #shorts", want: models.FormatShort},
+		{name: "landscape under three minutes", duration: "PT2M30S", want: models.FormatStandard},
+		{name: "vertical over three minutes", duration: "PT3M1S", vertical: true, want: models.FormatStandard},
+		{name: "regular upload", duration: "PT12M", want: models.FormatStandard},
+		{name: "missing duration", want: ""},
+		{
+			name: "live now", duration: "P0D", broadcast: "live",
+			live: &youtube.VideoLiveStreamingDetails{ActualStartTime: "2026-01-14T10:00:00Z"},
+			want: models.FormatLive,
+		},
+		{
+			name: "upcoming stream", duration: "P0D", broadcast: "upcoming",
+			live: &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-15T10:00:00Z"},
+			want: models.FormatLive,
+		},
+		{
+			name: "upcoming premiere", duration: "PT10M", broadcast: "upcoming",
+			live: &youtube.VideoLiveStreamingDetails{ScheduledStartTime: "2026-01-15T10:00:00Z"},
+			want: models.FormatPremiere,
+		},
+		{
+			name: "stream without end time", duration: "P0D", broadcast: "none",
+			live: &youtube.VideoLiveStreamingDetails{ActualStartTime: "2026-01-14T10:00:00Z"},
+			want: models.FormatLive,
+		},
+		{
+			name: "ended stream", duration: "PT2H5M", broadcast: "none",
+			live: &youtube.VideoLiveStreamingDetails{ActualStartTime: "2026-01-14T10:00:00Z", ActualEndTime: "2026-01-14T12:05:00Z"},
+			want: models.FormatStandard,
+		},
+		{
+			name: "completed premiere of a short", duration: "PT50S", broadcast: "none",
+			live: &youtube.VideoLiveStreamingDetails{ActualStartTime: "2026-01-14T10:00:00Z", ActualEndTime: "2026-01-14T10:00:50Z"},
+			want: models.FormatShort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &youtube.Video{
+				ContentDetails:       &youtube.VideoContentDetails{Duration: tt.duration},
+				LiveStreamingDetails: tt.live,
+			}
+			if tt.vertical {
+				item.Player = &youtube.VideoPlayer{EmbedWidth: 270, EmbedHeight: 480}
+			} else {
+				item.Player = &youtube.VideoPlayer{EmbedWidth: 480, EmbedHeight: 270}
+			}
+
+			video := &models.Video{Title: tt.title, LiveBroadcastContent: tt.broadcast}
+			video.DurationSeconds, _ = parseISODuration(tt.duration)
+
+			if got := classifyFormat(video, item); got != tt.want {
+				t.Errorf("classifyFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/search"
+)
+
+// Orders in which saved search matches can be returned
+var savedSearchSorts = map[string]bool{
+	"latest": true, "oldest": true, "title": true, "channel": true,
+	"shortest": true, "longest": true,
+}
+
+// Filter parameters that only make sense for a single request
+var unsavableFilters = map[string]bool{"page": true, "page_size": true, "cursor": true, "q": true, "sort": true}
+
+// ValidateSavedSearch checks a saved search before it is stored through the API
+func ValidateSavedSearch(savedSearch *models.SavedSearch) error {
+	if strings.TrimSpace(savedSearch.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if savedSearch.Sort == "" {
+		savedSearch.Sort = "latest"
+	}
+	if !savedSearchSorts[savedSearch.Sort] {
+		return fmt.Errorf("invalid sort %q (expected latest, oldest, title, channel, shortest or longest)", savedSearch.Sort)
+	}
+	params := make([]string, 0, len(savedSearch.Filters))
+	for param := range savedSearch.Filters {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	for _, param := range params {
+		if unsavableFilters[param] {
+			return fmt.Errorf("%s cannot be saved as a filter", param)
+		}
+		// A misspelt filter would otherwise be ignored and match every video
+		if !repository.IsVideoFilterParam(param) {
+			return fmt.Errorf("unknown filter %q", param)
+		}
+	}
+
+	_, _, err := CompileSavedSearch(savedSearch, nil)
+	return err
+}
+
+// CompileSavedSearch parses the stored query and filters, expanding
+// synonyms when a synonym service is given
+func CompileSavedSearch(savedSearch *models.SavedSearch, synonyms *SynonymService) (*search.Query, repository.VideoFilter, error) {
+	query, err := search.ParseQuery(savedSearch.Query)
+	if err != nil {
+		return nil, repository.VideoFilter{}, fmt.Errorf("invalid query: %w", err)
+	}
+	if synonyms != nil {
+		query = synonyms.Expand(query)
+	}
+
+	values := url.Values{}
+	for param, value := range savedSearch.Filters {
+		values.Set(param, value)
+	}
+	filter, err := repository.ParseVideoFilter(values)
+	if err != nil {
+		return nil, repository.VideoFilter{}, fmt.Errorf("invalid filters: %w", err)
+	}
+
+	return query, filter, nil
+}
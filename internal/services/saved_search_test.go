@@ -0,0 +1,43 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestValidateSavedSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		savedSearch models.SavedSearch
+		wantErr     string
+	}{
+		{name: "valid", savedSearch: models.SavedSearch{Name: "wc", Query: `"world cup" -highlights`, Filters: map[string]string{"lang": "hi", "channel_id": "UCa"}}},
+		{name: "missing name", savedSearch: models.SavedSearch{Name: " ", Query: "cricket"}, wantErr: "name is required"},
+		{name: "invalid sort", savedSearch: models.SavedSearch{Name: "wc", Query: "cricket", Sort: "relevance"}, wantErr: "invalid sort"},
+		{name: "invalid query", savedSearch: models.SavedSearch{Name: "wc", Query: `"world cup`}, wantErr: "invalid query"},
+		// Per-request parameters are rejected before unknown ones
+		{name: "pagination filter", savedSearch: models.SavedSearch{Name: "wc", Query: "cricket", Filters: map[string]string{"page": "2"}}, wantErr: "page cannot be saved"},
+		{name: "unknown filter", savedSearch: models.SavedSearch{Name: "wc", Query: "cricket", Filters: map[string]string{"langauge": "hi"}}, wantErr: `unknown filter "langauge"`},
+		{name: "invalid filter value", savedSearch: models.SavedSearch{Name: "wc", Query: "cricket", Filters: map[string]string{"lang": "klingon"}}, wantErr: "invalid filters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSavedSearch(&tt.savedSearch)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateSavedSearch() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateSavedSearch() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The sort defaults to latest
+	savedSearch := models.SavedSearch{Name: "wc", Query: "cricket"}
+	if err := ValidateSavedSearch(&savedSearch); err != nil || savedSearch.Sort != "latest" {
+		t.Errorf("Sort = %q (error %v), want latest", savedSearch.Sort, err)
+	}
+}
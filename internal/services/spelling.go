@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Redis hash of word -> number of stored videos containing it
+const vocabularyKey = "vocabulary:terms"
+
+// SpellingService keeps the search vocabulary (word document counts from
+// stored titles and descriptions) in Redis and corrects queries against an
+// in-memory copy, reloaded periodically so every instance sees words
+// ingested by the others.
+type SpellingService struct {
+	redisClient    *redis.Client
+	reloadInterval time.Duration
+
+	loadMu sync.Mutex // Serializes loads from Redis; mu is only held to swap the result
+
+	mu         sync.Mutex
+	vocabulary *search.Vocabulary
+	loadedAt   time.Time
+	reloading  bool
+}
+
+func NewSpellingService(redisClient *redis.Client, reloadInterval time.Duration) *SpellingService {
+	return &SpellingService{
+		redisClient:    redisClient,
+		reloadInterval: reloadInterval,
+		vocabulary:     search.NewVocabulary(),
+	}
+}
+
+// VideoStored adds a new video's words to the vocabulary. It satisfies the
+// fetcher's IngestListener interface.
+func (ss *SpellingService) VideoStored(video *models.Video) error {
+	terms, err := ss.RecordVideo(context.Background(), video)
+	if err != nil {
+		return err
+	}
+
+	ss.mu.Lock()
+	vocabulary := ss.vocabulary
+	ss.mu.Unlock()
+	for _, term := range terms {
+		vocabulary.Add(term, 1)
+	}
+	return nil
+}
+
+// RecordVideo counts the distinct title and description words of a video
+// in Redis and returns them
+func (ss *SpellingService) RecordVideo(ctx context.Context, video *models.Video) ([]string, error) {
+	terms := search.VocabularyTerms(video.Title, video.Description)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	pipe := ss.redisClient.Pipeline()
+	for _, term := range terms {
+		pipe.HIncrBy(ctx, vocabularyKey, term, 1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to update vocabulary: %w", err)
+	}
+	return terms, nil
+}
+
+// Reset deletes the stored vocabulary, e.g. before a full rebuild
+func (ss *SpellingService) Reset(ctx context.Context) error {
+	if err := ss.redisClient.Unlink(ctx, vocabularyKey).Err(); err != nil {
+		return fmt.Errorf("failed to delete vocabulary: %w", err)
+	}
+	return nil
+}
+
+// CorrectQuery returns the query with misspelled words replaced by the
+// closest vocabulary words, and whether anything changed
+func (ss *SpellingService) CorrectQuery(ctx context.Context, query *search.Query) (string, bool, error) {
+	vocabulary, err := ss.currentVocabulary(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	corrected, changed := search.CorrectQuery(query, vocabulary.Correct)
+	return corrected, changed, nil
+}
+
+// currentVocabulary returns the in-memory vocabulary. Once it is older than
+// the reload interval it keeps being served while a fresh copy loads from
+// Redis in the background; only the first query waits for a load.
+func (ss *SpellingService) currentVocabulary(ctx context.Context) (*search.Vocabulary, error) {
+	ss.mu.Lock()
+	vocabulary, loadedAt := ss.vocabulary, ss.loadedAt
+	stale := !loadedAt.IsZero() && time.Since(loadedAt) >= ss.reloadInterval && !ss.reloading
+	if stale {
+		ss.reloading = true
+	}
+	ss.mu.Unlock()
+
+	if stale {
+		go func() {
+			if err := ss.reload(context.Background()); err != nil {
+				log.Printf("⚠️ %v", err)
+			}
+			ss.mu.Lock()
+			ss.reloading = false
+			ss.mu.Unlock()
+		}()
+	}
+	if !loadedAt.IsZero() {
+		return vocabulary, nil
+	}
+
+	if err := ss.reload(ctx); err != nil {
+		return nil, err
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.vocabulary, nil
+}
+
+// reload loads the vocabulary from Redis and swaps it in, unless another
+// caller did so while this one waited
+func (ss *SpellingService) reload(ctx context.Context) error {
+	ss.loadMu.Lock()
+	defer ss.loadMu.Unlock()
+
+	ss.mu.Lock()
+	fresh := !ss.loadedAt.IsZero() && time.Since(ss.loadedAt) < ss.reloadInterval
+	ss.mu.Unlock()
+	if fresh {
+		return nil
+	}
+
+	start := time.Now()
+	vocabulary, err := ss.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	ss.mu.Lock()
+	ss.vocabulary = vocabulary
+	ss.loadedAt = time.Now()
+	ss.mu.Unlock()
+	log.Printf("📖 Loaded search vocabulary: %d words (took %v)", vocabulary.Len(), time.Since(start))
+
+	return nil
+}
+
+func (ss *SpellingService) load(ctx context.Context) (*search.Vocabulary, error) {
+	// HSCAN may return a field more than once, so dedupe before adding
+	counts := make(map[string]int64)
+
+	var cursor uint64
+	for {
+		fields, next, err := ss.redisClient.HScan(ctx, vocabularyKey, cursor, "*", 1000).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load vocabulary: %w", err)
+		}
+
+		// HSCAN replies with alternating field and value
+		for i := 0; i+1 < len(fields); i += 2 {
+			count, err := strconv.ParseInt(fields[i+1], 10, 64)
+			if err != nil {
+				continue
+			}
+			counts[fields[i]] = count
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	vocabulary := search.NewVocabulary()
+	for word, count := range counts {
+		vocabulary.Add(word, count)
+	}
+	return vocabulary, nil
+}
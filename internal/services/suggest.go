@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+const (
+	// One sorted set of completions per normalized prefix
+	suggestKeyPrefix = "suggest:prefix:"
+
+	// Prefixes longer than this share the longest prefix's set
+	maxSuggestPrefixLength = 15
+
+	// Completions kept per prefix (lowest scores are trimmed)
+	suggestionsPerPrefix = 50
+
+	// Shortest title word offered as a completion
+	minSuggestTermLength = 3
+)
+
+// Decay reference point; scores are relative, so any fixed time works
+var suggestEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// addSuggestionScript adds 2^weight to the log2 score of a member
+// (log2(2^a + 2^b) = max + log2(1 + 2^(min-max))) and trims the set
+var addSuggestionScript = redis.NewScript(`
+local weight = tonumber(ARGV[2])
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score then
+	score = tonumber(score)
+	local high, low = math.max(score, weight), math.min(score, weight)
+	weight = high + math.log(1 + 2 ^ (low - high)) / math.log(2)
+end
+redis.call('ZADD', KEYS[1], weight, ARGV[1])
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -tonumber(ARGV[3]) - 1)
+return 1
+`)
+
+// SuggestService serves type-ahead completions from Redis sorted sets, one
+// per normalized prefix. Each occurrence of a term adds 2^(age/halfLife)
+// to its score, so recent terms outrank ones that were frequent long ago
+// without rewriting old scores. Scores are stored as log2 values, which
+// grow linearly with time instead of overflowing.
+type SuggestService struct {
+	redisClient *redis.Client
+	halfLife    time.Duration
+}
+
+func NewSuggestService(redisClient *redis.Client, halfLife time.Duration) *SuggestService {
+	if halfLife <= 0 {
+		halfLife = 7 * 24 * time.Hour
+	}
+	return &SuggestService{
+		redisClient: redisClient,
+		halfLife:    halfLife,
+	}
+}
+
+// VideoStored indexes a new video's title words and channel name. It
+// satisfies the fetcher's IngestListener interface.
+func (ss *SuggestService) VideoStored(video *models.Video) error {
+	return ss.RecordVideo(context.Background(), video)
+}
+
+// RecordVideo indexes a video, weighting it by its publish time
+func (ss *SuggestService) RecordVideo(ctx context.Context, video *models.Video) error {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range search.Words(video.Title) {
+		if seen[word] || len([]rune(word)) < minSuggestTermLength || search.IsStopWord(word) || isNumber(word) {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+
+	weight := ss.weight(video.PublishedAt)
+	pipe := ss.redisClient.Pipeline()
+	for _, term := range terms {
+		ss.add(ctx, pipe, models.SuggestionTitleTerm, term, weight)
+	}
+	if video.ChannelTitle != "" {
+		ss.add(ctx, pipe, models.SuggestionChannel, video.ChannelTitle, weight)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index suggestions: %w", err)
+	}
+	return nil
+}
+
+// RecordSearchQueries indexes the configured background search queries
+func (ss *SuggestService) RecordSearchQueries(ctx context.Context, queries []string) error {
+	weight := ss.weight(time.Now())
+	pipe := ss.redisClient.Pipeline()
+	for _, query := range queries {
+		ss.add(ctx, pipe, models.SuggestionSearchQuery, query, weight)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index search query suggestions: %w", err)
+	}
+	return nil
+}
+
+// Reset deletes every prefix set, e.g. before a full rebuild
+func (ss *SuggestService) Reset(ctx context.Context) error {
+	iter := ss.redisClient.Scan(ctx, 0, suggestKeyPrefix+"*", 1000).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 1000 {
+			if err := ss.redisClient.Unlink(ctx, keys...).Err(); err != nil {
+				return fmt.Errorf("failed to delete suggestions: %w", err)
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan suggestions: %w", err)
+	}
+
+	if len(keys) > 0 {
+		if err := ss.redisClient.Unlink(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("failed to delete suggestions: %w", err)
+		}
+	}
+	return nil
+}
+
+// Suggest returns up to limit completions for a partially typed query
+func (ss *SuggestService) Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error) {
+	normalized := normalizeSuggestion(prefix)
+	suggestions := []models.Suggestion{}
+	if normalized == "" {
+		return suggestions, nil
+	}
+
+	runes := []rune(normalized)
+	key := suggestKeyPrefix + string(runes[:minInt(len(runes), maxSuggestPrefixLength)])
+
+	// Read the whole set: long prefixes are filtered below and kinds may repeat a text
+	members, err := ss.redisClient.ZRevRangeWithScores(ctx, key, 0, suggestionsPerPrefix-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suggestions: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, member := range members {
+		kind, text, ok := strings.Cut(fmt.Sprint(member.Member), ":")
+		if !ok {
+			continue
+		}
+
+		normalizedText := normalizeSuggestion(text)
+		if seen[normalizedText] || !matchesSuggestion(normalizedText, normalized) {
+			continue
+		}
+		seen[normalizedText] = true
+
+		suggestions = append(suggestions, models.Suggestion{Text: text, Kind: kind, Score: ss.relativeScore(member.Score)})
+		if len(suggestions) == limit {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// add records one occurrence of a suggestion in the pipeline
+func (ss *SuggestService) add(ctx context.Context, pipe redis.Pipeliner, kind, text string, weight float64) {
+	member, keys := suggestionEntry(kind, text)
+	for _, key := range keys {
+		addSuggestionScript.Eval(ctx, pipe, []string{key}, member, weight, suggestionsPerPrefix)
+	}
+}
+
+// suggestionEntry returns the set member of a suggestion and the prefix
+// sets it belongs to: every prefix of the text and of each of its later
+// words, so "spo" also suggests "Star Sports"
+func suggestionEntry(kind, text string) (string, []string) {
+	normalized := normalizeSuggestion(text)
+	if normalized == "" {
+		return "", nil
+	}
+
+	member := kind + ":" + strings.TrimSpace(text)
+	if kind == models.SuggestionTitleTerm {
+		member = kind + ":" + normalized
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+	words := strings.Fields(normalized)
+	for i := range words {
+		runes := []rune(strings.Join(words[i:], " "))
+		for length := 1; length <= len(runes) && length <= maxSuggestPrefixLength; length++ {
+			key := suggestKeyPrefix + string(runes[:length])
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return member, keys
+}
+
+// weight is the log2 of an occurrence's score, (t-epoch)/halfLife, capped
+// at now so future-dated videos don't dominate
+func (ss *SuggestService) weight(t time.Time) float64 {
+	if t.IsZero() || t.After(time.Now()) {
+		t = time.Now()
+	}
+	return float64(t.Sub(suggestEpoch)) / float64(ss.halfLife)
+}
+
+// relativeScore converts a stored log2 score to occurrences-at-now equivalents
+func (ss *SuggestService) relativeScore(score float64) float64 {
+	scaled := math.Exp2(score - ss.weight(time.Now()))
+	return math.Round(scaled*1000) / 1000
+}
+
+func normalizeSuggestion(text string) string {
+	return strings.Join(search.Words(text), " ")
+}
+
+// matchesSuggestion checks the whole typed prefix (sets are keyed by at
+// most maxSuggestPrefixLength runes) against the text or one of its words
+func matchesSuggestion(text, prefix string) bool {
+	words := strings.Fields(text)
+	for i := range words {
+		if strings.HasPrefix(strings.Join(words[i:], " "), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestSuggestionEntry(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		text       string
+		wantMember string
+		wantKeys   []string
+	}{
+		{
+			// Title words are stored normalized
+			name:       "title term",
+			kind:       models.SuggestionTitleTerm,
+			text:       "Café",
+			wantMember: "title_term:cafe",
+			wantKeys:   []string{"suggest:prefix:c", "suggest:prefix:ca", "suggest:prefix:caf", "suggest:prefix:cafe"},
+		},
+		{
+			// Later words are prefixes too; the member keeps the original text
+			name:       "channel",
+			kind:       models.SuggestionChannel,
+			text:       " Star Sports ",
+			wantMember: "channel:Star Sports",
+			wantKeys: []string{
+				"suggest:prefix:s", "suggest:prefix:st", "suggest:prefix:sta", "suggest:prefix:star",
+				"suggest:prefix:star ", "suggest:prefix:star s", "suggest:prefix:star sp", "suggest:prefix:star spo",
+				"suggest:prefix:star spor", "suggest:prefix:star sport", "suggest:prefix:star sports",
+				"suggest:prefix:sp", "suggest:prefix:spo", "suggest:prefix:spor", "suggest:prefix:sport", "suggest:prefix:sports",
+			},
+		},
+		{
+			name:       "long text",
+			kind:       models.SuggestionSearchQuery,
+			text:       "highlightsreplay",
+			wantMember: "search_query:highlightsreplay",
+			wantKeys: []string{
+				"suggest:prefix:h", "suggest:prefix:hi", "suggest:prefix:hig", "suggest:prefix:high", "suggest:prefix:highl",
+				"suggest:prefix:highli", "suggest:prefix:highlig", "suggest:prefix:highligh", "suggest:prefix:highlight",
+				"suggest:prefix:highlights", "suggest:prefix:highlightsr", "suggest:prefix:highlightsre",
+				"suggest:prefix:highlightsrep", "suggest:prefix:highlightsrepl", "suggest:prefix:highlightsrepla",
+			},
+		},
+		{name: "no words", kind: models.SuggestionChannel, text: " - "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member, keys := suggestionEntry(tt.kind, tt.text)
+			if member != tt.wantMember {
+				t.Errorf("member = %q, want %q", member, tt.wantMember)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("keys =\n  %q\nwant\n  %q", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestMatchesSuggestion(t *testing.T) {
+	tests := []struct {
+		text   string
+		prefix string
+		want   bool
+	}{
+		{"star sports", "sta", true},
+		{"star sports", "spo", true},
+		{"star sports", "star spo", true},
+		{"star sports", "tar", false},
+		{"star sports", "sports star", false},
+		// Sets are keyed by 15 runes; the rest of the prefix is checked here
+		{"highlightsreplay", "highlightsreplay", true},
+		{"highlightsrepla", "highlightsreplay", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesSuggestion(tt.text, tt.prefix); got != tt.want {
+			t.Errorf("matchesSuggestion(%q, %q) = %v, want %v", tt.text, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestWeight(t *testing.T) {
+	ss := NewSuggestService(nil, 24*time.Hour)
+	now := ss.weight(time.Now())
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want float64
+	}{
+		{name: "one half-life per day", t: suggestEpoch.Add(48 * time.Hour), want: 2},
+		{name: "epoch", t: suggestEpoch, want: 0},
+		{name: "before the epoch", t: suggestEpoch.Add(-12 * time.Hour), want: -0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ss.weight(tt.t); got != tt.want {
+				t.Errorf("weight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Unknown and future times count as now
+	for _, at := range []time.Time{{}, time.Now().Add(365 * 24 * time.Hour)} {
+		if got := ss.weight(at); got < now || got-now > 0.001 {
+			t.Errorf("weight(%v) = %v, want about %v", at, got, now)
+		}
+	}
+}
+
+func TestSuggestRelativeScore(t *testing.T) {
+	ss := NewSuggestService(nil, 24*time.Hour)
+	now := ss.weight(time.Now())
+
+	tests := []struct {
+		name  string
+		score float64
+		want  float64
+	}{
+		{name: "one occurrence now", score: now, want: 1},
+		{name: "one occurrence a half-life ago", score: now - 1, want: 0.5},
+		{name: "four occurrences now", score: now + 2, want: 4},
+		{name: "long ago", score: now - 20, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ss.relativeScore(tt.score); got != tt.want {
+				t.Errorf("relativeScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/search"
+)
+
+// How long a loaded dictionary is used before reloading, so edits made
+// through another instance's admin API reach this one
+const synonymReloadInterval = time.Minute
+
+// Limits of a synonym group edited through the admin API
+const (
+	maxSynonymTerms      = 50
+	maxSynonymTermLength = 100
+)
+
+// SynonymService applies the synonym and transliteration dictionary to
+// search queries and stored videos
+type SynonymService struct {
+	synonymRepo *repository.SynonymRepository
+	videoRepo   *repository.VideoRepository
+
+	mutex      sync.RWMutex
+	dictionary *search.Dictionary
+	loadedAt   time.Time
+
+	reindexMutex   sync.Mutex
+	reindexRunning bool
+	reindexPending bool
+}
+
+func NewSynonymService(synonymRepo *repository.SynonymRepository, videoRepo *repository.VideoRepository) *SynonymService {
+	return &SynonymService{
+		synonymRepo: synonymRepo,
+		videoRepo:   videoRepo,
+		dictionary:  search.NewDictionary(nil),
+	}
+}
+
+// ValidateSynonymGroup checks a group before it is saved through the admin API
+func ValidateSynonymGroup(group *models.SynonymGroup) error {
+	if len(group.Terms) > maxSynonymTerms {
+		return fmt.Errorf("a group can have at most %d terms", maxSynonymTerms)
+	}
+
+	seen := make(map[string]bool)
+	for _, term := range group.Terms {
+		normalized := strings.Join(search.Words(term), " ")
+		if normalized == "" {
+			return fmt.Errorf("term %q has no letters or digits", term)
+		}
+		if len([]rune(term)) > maxSynonymTermLength {
+			return fmt.Errorf("term %q is longer than %d characters", term, maxSynonymTermLength)
+		}
+		if seen[normalized] {
+			return fmt.Errorf("term %q is listed twice", term)
+		}
+		seen[normalized] = true
+	}
+
+	if len(seen) < 2 {
+		return fmt.Errorf("at least two different terms are required")
+	}
+	return nil
+}
+
+// Reload fetches the enabled groups from the database
+func (ss *SynonymService) Reload() error {
+	dictionary, err := ss.load()
+	if err != nil {
+		return err
+	}
+	ss.install(dictionary)
+	return nil
+}
+
+func (ss *SynonymService) load() (*search.Dictionary, error) {
+	groups, err := ss.synonymRepo.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	dictionaryGroups := make([]search.SynonymGroup, 0, len(groups))
+	for _, group := range groups {
+		dictionaryGroups = append(dictionaryGroups, search.SynonymGroup{
+			Token: search.SynonymToken(group.ID.Hex()),
+			Terms: group.Terms,
+		})
+	}
+	return search.NewDictionary(dictionaryGroups), nil
+}
+
+func (ss *SynonymService) install(dictionary *search.Dictionary) {
+	ss.mutex.Lock()
+	ss.dictionary = dictionary
+	ss.loadedAt = time.Now()
+	ss.mutex.Unlock()
+}
+
+// current returns the dictionary, reloading it once it is stale. A failed
+// reload keeps serving the previous dictionary, and so does a reindex in
+// progress, which installs its dictionary when done.
+func (ss *SynonymService) current() *search.Dictionary {
+	ss.mutex.RLock()
+	dictionary, loadedAt := ss.dictionary, ss.loadedAt
+	ss.mutex.RUnlock()
+
+	if time.Since(loadedAt) < synonymReloadInterval || ss.reindexing() {
+		return dictionary
+	}
+	if err := ss.Reload(); err != nil {
+		log.Printf("⚠️ Failed to reload synonym dictionary: %v", err)
+		ss.mutex.Lock()
+		ss.loadedAt = time.Now() // Retry after the next interval
+		ss.mutex.Unlock()
+		return dictionary
+	}
+
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	return ss.dictionary
+}
+
+// Expand replaces query terms found in the dictionary with synonym nodes
+func (ss *SynonymService) Expand(query *search.Query) *search.Query {
+	return ss.current().Expand(query)
+}
+
+// Tokens returns the synonym markers stored in a video's search_terms
+func (ss *SynonymService) Tokens(video *models.Video) []string {
+	return ss.current().Tokens(video.Title, video.Description)
+}
+
+// ReindexVideos loads the edited dictionary and rewrites search_terms of
+// stored videos for it in the background. Queries switch to the new
+// dictionary once the markers are written. Calls made while a run is in
+// progress schedule one more run, so the last edit is always applied.
+func (ss *SynonymService) ReindexVideos() {
+	ss.reindexMutex.Lock()
+	if ss.reindexRunning {
+		ss.reindexPending = true
+		ss.reindexMutex.Unlock()
+		return
+	}
+	ss.reindexRunning = true
+	ss.reindexMutex.Unlock()
+
+	go func() {
+		for {
+			start := time.Now()
+			dictionary, err := ss.load()
+			if err != nil {
+				log.Printf("❌ Failed to load synonym dictionary for reindex: %v", err)
+			} else {
+				updated, err := ss.videoRepo.ReindexSearchTerms(func(video *models.Video) []string {
+					return dictionary.Tokens(video.Title, video.Description)
+				})
+				if err != nil {
+					// Queries still match the literal variants of new groups
+					log.Printf("❌ Synonym reindex failed after %d videos: %v", updated, err)
+				} else {
+					log.Printf("🔤 Synonym reindex updated %d videos (took %v)", updated, time.Since(start))
+				}
+				ss.install(dictionary)
+			}
+
+			ss.reindexMutex.Lock()
+			if !ss.reindexPending {
+				ss.reindexRunning = false
+				ss.reindexMutex.Unlock()
+				return
+			}
+			ss.reindexPending = false
+			ss.reindexMutex.Unlock()
+		}
+	}()
+}
+
+func (ss *SynonymService) reindexing() bool {
+	ss.reindexMutex.Lock()
+	defer ss.reindexMutex.Unlock()
+	return ss.reindexRunning
+}
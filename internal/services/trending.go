@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// FetchTrending returns YouTube's mostPopular chart for a region and
+// category (1 quota unit). Category "0" is the overall chart.
+func (ys *YouTubeService) FetchTrending(region, categoryID string, maxResults int) ([]models.TrendingEntry, error) {
+	var response *youtube.VideoListResponse
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		call := service.Videos.List([]string{"snippet", "statistics"}).
+			Chart("mostPopular").
+			RegionCode(region).
+			MaxResults(int64(maxResults))
+		if categoryID != "" && categoryID != models.TrendingAllCategories {
+			call = call.VideoCategoryId(categoryID)
+		}
+
+		var err error
+		response, err = call.Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trending chart: %w", err)
+	}
+
+	entries := make([]models.TrendingEntry, 0, len(response.Items))
+	for i, item := range response.Items {
+		entry := models.TrendingEntry{
+			Rank:    i + 1,
+			VideoID: item.Id,
+		}
+		if item.Snippet != nil {
+			entry.Title = item.Snippet.Title
+			entry.ChannelID = item.Snippet.ChannelId
+			entry.ChannelTitle = item.Snippet.ChannelTitle
+			entry.PublishedAt, _ = time.Parse(time.RFC3339, item.Snippet.PublishedAt)
+			if item.Snippet.Thumbnails != nil {
+				entry.ThumbnailURL = models.Thumbnail{
+					Default: getThumbnailURL(item.Snippet.Thumbnails.Default),
+					Medium:  getThumbnailURL(item.Snippet.Thumbnails.Medium),
+					High:    getThumbnailURL(item.Snippet.Thumbnails.High),
+				}
+			}
+		}
+		if item.Statistics != nil {
+			entry.ViewCount = int64(item.Statistics.ViewCount)
+			entry.LikeCount = int64(item.Statistics.LikeCount)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
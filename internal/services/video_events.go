@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+
+	"fampay-youtube-api/internal/models"
+)
+
+// Redis pub/sub channel carrying newly stored videos to every replica
+const videoEventsChannel = "videos:new"
+
+// ErrTooManySubscribers is returned when the live feed is at capacity
+var ErrTooManySubscribers = errors.New("too many live feed subscribers")
+
+// VideoEventFilter selects the videos a live feed subscriber receives.
+// Empty lists match every video; a video must pass each non-empty list.
+// With MatchAny the lists are topics instead: a video matches when it is
+// in either list, and empty lists match nothing.
+type VideoEventFilter struct {
+	Queries    []string // Search queries the video was fetched for
+	ChannelIDs []string
+	MatchAny   bool
+}
+
+func (f VideoEventFilter) Matches(video *models.Video) bool {
+	if f.MatchAny {
+		return containsString(f.Queries, video.SearchQuery) || containsString(f.ChannelIDs, video.ChannelID)
+	}
+	if len(f.Queries) > 0 && !containsString(f.Queries, video.SearchQuery) {
+		return false
+	}
+	if len(f.ChannelIDs) > 0 && !containsString(f.ChannelIDs, video.ChannelID) {
+		return false
+	}
+	return true
+}
+
+// VideoSubscription receives newly stored videos matching its filter.
+// Events is closed when the subscription ends: on Close, on shutdown, or
+// when the subscriber fell a full buffer behind (Overflowed).
+type VideoSubscription struct {
+	Events <-chan *models.Video
+
+	events      chan *models.Video
+	broadcaster *VideoBroadcaster
+	filterMutex sync.RWMutex
+	filter      VideoEventFilter
+	overflowed  bool // Guarded by the broadcaster's mutex
+}
+
+// SetFilter replaces the subscription's filter for subsequent videos
+func (s *VideoSubscription) SetFilter(filter VideoEventFilter) {
+	s.filterMutex.Lock()
+	s.filter = filter
+	s.filterMutex.Unlock()
+}
+
+func (s *VideoSubscription) matches(video *models.Video) bool {
+	s.filterMutex.RLock()
+	defer s.filterMutex.RUnlock()
+	return s.filter.Matches(video)
+}
+
+// Overflowed reports whether the subscription was dropped for falling behind
+func (s *VideoSubscription) Overflowed() bool {
+	s.broadcaster.mutex.Lock()
+	defer s.broadcaster.mutex.Unlock()
+	return s.overflowed
+}
+
+// Close ends the subscription
+func (s *VideoSubscription) Close() {
+	s.broadcaster.remove(s)
+}
+
+// VideoBroadcaster fans newly stored videos out to live feed subscribers.
+// The fetcher's videos are published to Redis and every replica relays
+// what it receives to its own subscribers, so clients see videos stored
+// by any instance.
+type VideoBroadcaster struct {
+	redisClient    *redis.Client
+	maxSubscribers int
+	bufferSize     int
+	stopChan       chan struct{}
+
+	mutex       sync.Mutex
+	subscribers map[*VideoSubscription]struct{}
+}
+
+func NewVideoBroadcaster(redisClient *redis.Client, maxSubscribers, bufferSize int) *VideoBroadcaster {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &VideoBroadcaster{
+		redisClient:    redisClient,
+		maxSubscribers: maxSubscribers,
+		bufferSize:     bufferSize,
+		stopChan:       make(chan struct{}),
+		subscribers:    make(map[*VideoSubscription]struct{}),
+	}
+}
+
+// VideoStored publishes a new video to every replica. It satisfies the
+// fetcher's IngestListener interface. When Redis is unavailable the video
+// still reaches this instance's subscribers.
+func (vb *VideoBroadcaster) VideoStored(video *models.Video) error {
+	payload, err := json.Marshal(video)
+	if err != nil {
+		return fmt.Errorf("failed to encode video event: %w", err)
+	}
+
+	if err := vb.redisClient.Publish(context.Background(), videoEventsChannel, payload).Err(); err != nil {
+		vb.broadcast(video)
+		return fmt.Errorf("failed to publish video event: %w", err)
+	}
+	return nil
+}
+
+// Start relays videos published by any replica to local subscribers
+func (vb *VideoBroadcaster) Start() {
+	log.Println("📡 Starting live video feed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The subscription reconnects on its own after Redis errors
+	pubsub := vb.redisClient.Subscribe(ctx, videoEventsChannel)
+	defer pubsub.Close()
+	messages := pubsub.Channel()
+
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var video models.Video
+			if err := json.Unmarshal([]byte(msg.Payload), &video); err != nil {
+				log.Printf("⚠️ Ignoring malformed video event: %v", err)
+				continue
+			}
+			vb.broadcast(&video)
+		case <-vb.stopChan:
+			vb.closeAll()
+			log.Println("Live video feed stopped")
+			return
+		}
+	}
+}
+
+func (vb *VideoBroadcaster) Stop() {
+	close(vb.stopChan)
+}
+
+// Subscribe registers a subscriber, failing when the feed is at capacity
+func (vb *VideoBroadcaster) Subscribe(filter VideoEventFilter) (*VideoSubscription, error) {
+	vb.mutex.Lock()
+	defer vb.mutex.Unlock()
+
+	if vb.maxSubscribers > 0 && len(vb.subscribers) >= vb.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	events := make(chan *models.Video, vb.bufferSize)
+	subscription := &VideoSubscription{
+		Events:      events,
+		events:      events,
+		broadcaster: vb,
+		filter:      filter,
+	}
+	vb.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// Subscribers returns the number of local subscribers
+func (vb *VideoBroadcaster) Subscribers() int {
+	vb.mutex.Lock()
+	defer vb.mutex.Unlock()
+	return len(vb.subscribers)
+}
+
+// broadcast hands a video to every matching subscriber without blocking.
+// A subscriber whose buffer is full is dropped rather than slowing the
+// others down; it can resume from the last video it received.
+func (vb *VideoBroadcaster) broadcast(video *models.Video) {
+	vb.mutex.Lock()
+	defer vb.mutex.Unlock()
+
+	for subscription := range vb.subscribers {
+		if !subscription.matches(video) {
+			continue
+		}
+		select {
+		case subscription.events <- video:
+		default:
+			subscription.overflowed = true
+			delete(vb.subscribers, subscription)
+			close(subscription.events)
+		}
+	}
+}
+
+func (vb *VideoBroadcaster) remove(subscription *VideoSubscription) {
+	vb.mutex.Lock()
+	defer vb.mutex.Unlock()
+
+	if _, ok := vb.subscribers[subscription]; !ok {
+		return // Already dropped
+	}
+	delete(vb.subscribers, subscription)
+	close(subscription.events)
+}
+
+func (vb *VideoBroadcaster) closeAll() {
+	vb.mutex.Lock()
+	defer vb.mutex.Unlock()
+
+	for subscription := range vb.subscribers {
+		delete(vb.subscribers, subscription)
+		close(subscription.events)
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestVideoEventFilterMatches(t *testing.T) {
+	video := &models.Video{VideoID: "abc", SearchQuery: "cricket", ChannelID: "UCa"}
+
+	tests := []struct {
+		name   string
+		filter VideoEventFilter
+		want   bool
+	}{
+		{name: "no filter", filter: VideoEventFilter{}, want: true},
+		{name: "query", filter: VideoEventFilter{Queries: []string{"football", "cricket"}}, want: true},
+		{name: "other query", filter: VideoEventFilter{Queries: []string{"football"}}, want: false},
+		{name: "channel", filter: VideoEventFilter{ChannelIDs: []string{"UCa"}}, want: true},
+		{name: "query and channel", filter: VideoEventFilter{Queries: []string{"cricket"}, ChannelIDs: []string{"UCa"}}, want: true},
+		// Every non-empty list must match
+		{name: "query but other channel", filter: VideoEventFilter{Queries: []string{"cricket"}, ChannelIDs: []string{"UCb"}}, want: false},
+		// With MatchAny either list is enough, and empty lists match nothing
+		{name: "any: no topics", filter: VideoEventFilter{MatchAny: true}, want: false},
+		{name: "any: query", filter: VideoEventFilter{Queries: []string{"cricket"}, MatchAny: true}, want: true},
+		{name: "any: query but other channel", filter: VideoEventFilter{Queries: []string{"cricket"}, ChannelIDs: []string{"UCb"}, MatchAny: true}, want: true},
+		{name: "any: channel but other query", filter: VideoEventFilter{Queries: []string{"football"}, ChannelIDs: []string{"UCa"}, MatchAny: true}, want: true},
+		{name: "any: neither", filter: VideoEventFilter{Queries: []string{"football"}, ChannelIDs: []string{"UCb"}, MatchAny: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(video); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoBroadcasterOverflow(t *testing.T) {
+	broadcaster := NewVideoBroadcaster(nil, 2, 1)
+
+	slow, err := broadcaster.Subscribe(VideoEventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	football, err := broadcaster.Subscribe(VideoEventFilter{Queries: []string{"football"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := broadcaster.Subscribe(VideoEventFilter{}); !errors.Is(err, ErrTooManySubscribers) {
+		t.Fatalf("Subscribe() over the limit error = %v, want ErrTooManySubscribers", err)
+	}
+
+	// The second video doesn't fit the one-video buffer
+	first := &models.Video{VideoID: "first", SearchQuery: "cricket"}
+	broadcaster.broadcast(first)
+	broadcaster.broadcast(&models.Video{VideoID: "second", SearchQuery: "cricket"})
+
+	if video, ok := <-slow.Events; !ok || video != first {
+		t.Errorf("first event = %v, %v; want the first video", video, ok)
+	}
+	if _, ok := <-slow.Events; ok {
+		t.Error("Events still open after the buffer overflowed")
+	}
+	if !slow.Overflowed() {
+		t.Error("Overflowed() = false, want true")
+	}
+	slow.Close() // Already dropped
+
+	// Subscribers that didn't match keep their subscription
+	if football.Overflowed() || broadcaster.Subscribers() != 1 {
+		t.Errorf("football overflowed = %v with %d subscribers, want only the slow one dropped", football.Overflowed(), broadcaster.Subscribers())
+	}
+	if _, err := broadcaster.Subscribe(VideoEventFilter{}); err != nil {
+		t.Errorf("Subscribe() after a drop error = %v", err)
+	}
+
+	football.Close()
+	if _, ok := <-football.Events; ok || football.Overflowed() {
+		t.Error("Close() should close Events without marking an overflow")
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/search"
+)
+
+// Headers sent with every webhook request
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // sha256=<hex HMAC of "<timestamp>.<body>">
+	WebhookTimestampHeader = "X-Webhook-Timestamp" // Unix seconds when the attempt was signed
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery" // Same on every retry of a delivery
+)
+
+// Limits of a webhook edited through the admin API
+const (
+	maxWebhookFilterValues = 50
+	minWebhookSecretLength = 16
+)
+
+// ValidateWebhook checks a webhook before it is saved through the admin API
+func ValidateWebhook(webhook *models.Webhook) error {
+	if strings.TrimSpace(webhook.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+
+	if webhook.Secret != "" && len(webhook.Secret) < minWebhookSecretLength {
+		return fmt.Errorf("secret must be at least %d characters", minWebhookSecretLength)
+	}
+
+	filters := map[string][]string{
+		"queries":     webhook.Queries,
+		"channel_ids": webhook.ChannelIDs,
+		"keywords":    webhook.Keywords,
+	}
+	for name, values := range filters {
+		if len(values) > maxWebhookFilterValues {
+			return fmt.Errorf("%s can have at most %d values", name, maxWebhookFilterValues)
+		}
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("%s cannot contain empty values", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GenerateWebhookSecret returns a random signing key for a new webhook
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// WebhookMatches reports whether a stored video passes a webhook's filters
+func WebhookMatches(webhook *models.Webhook, video *models.Video) bool {
+	if len(webhook.Queries) > 0 && !containsFold(webhook.Queries, video.SearchQuery) {
+		return false
+	}
+	if len(webhook.ChannelIDs) > 0 && !containsFold(webhook.ChannelIDs, video.ChannelID) {
+		return false
+	}
+	if len(webhook.Keywords) > 0 {
+		text := " " + strings.Join(search.Words(video.Title+" "+video.Description), " ") + " "
+		for _, keyword := range webhook.Keywords {
+			if words := search.Words(keyword); len(words) > 0 && strings.Contains(text, " "+strings.Join(words, " ")+" ") {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// SignWebhookPayload returns the signature header value of a request body
+// sent at timestamp (Unix seconds)
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the signature and timestamp headers of a
+// received webhook. Requests signed more than tolerance ago are rejected
+// so a captured request can't be replayed later.
+func VerifyWebhookSignature(secret, signature, timestamp string, body []byte, tolerance time.Duration) error {
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", WebhookTimestampHeader)
+	}
+	if age := time.Since(time.Unix(sentAt, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("timestamp is outside the %v tolerance", tolerance)
+	}
+
+	expected := SignWebhookPayload(secret, sentAt, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+)
+
+const testWebhookSecret = "whsec_test_secret_1234"
+
+func TestSignWebhookPayload(t *testing.T) {
+	// HMAC-SHA256 of `1767225600.{"event":"ping"}`, as computed by openssl
+	want := "sha256=770a11c0bfd4f8d2e0e5635790a242e9f120d528850dbcf946dfe296547392a8"
+	if got := SignWebhookPayload(testWebhookSecret, 1767225600, []byte(`{"event":"ping"}`)); got != want {
+		t.Errorf("SignWebhookPayload() = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"event":"video.created"}`)
+	now := time.Now().Unix()
+	sign := func(timestamp int64) string {
+		return SignWebhookPayload(testWebhookSecret, timestamp, body)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		wantErr   bool
+	}{
+		{name: "valid", secret: testWebhookSecret, signature: sign(now), timestamp: strconv.FormatInt(now, 10), body: body},
+		{name: "within tolerance", secret: testWebhookSecret, signature: sign(now - 200), timestamp: strconv.FormatInt(now-200, 10), body: body},
+		{name: "wrong secret", secret: "whsec_other_secret_99", signature: sign(now), timestamp: strconv.FormatInt(now, 10), body: body, wantErr: true},
+		{name: "modified body", secret: testWebhookSecret, signature: sign(now), timestamp: strconv.FormatInt(now, 10), body: []byte(`{"event":"ping"}`), wantErr: true},
+		// The timestamp is signed, so it can't be moved forward to replay a request
+		{name: "replayed with new timestamp", secret: testWebhookSecret, signature: sign(now - 3600), timestamp: strconv.FormatInt(now, 10), body: body, wantErr: true},
+		{name: "too old", secret: testWebhookSecret, signature: sign(now - 3600), timestamp: strconv.FormatInt(now-3600, 10), body: body, wantErr: true},
+		{name: "from the future", secret: testWebhookSecret, signature: sign(now + 3600), timestamp: strconv.FormatInt(now+3600, 10), body: body, wantErr: true},
+		{name: "invalid timestamp", secret: testWebhookSecret, signature: sign(now), timestamp: "yesterday", body: body, wantErr: true},
+		{name: "missing signature", secret: testWebhookSecret, timestamp: strconv.FormatInt(now, 10), body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.signature, tt.timestamp, tt.body, 5*time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyWebhookSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookMatches(t *testing.T) {
+	video := &models.Video{
+		Title:       "India vs Australia: World Cup Final Highlights",
+		Description: "Watch the best moments.",
+		ChannelID:   "UCespn",
+		SearchQuery: "cricket",
+	}
+
+	tests := []struct {
+		name    string
+		webhook models.Webhook
+		want    bool
+	}{
+		{name: "no filters", want: true},
+		{name: "query", webhook: models.Webhook{Queries: []string{"football", " Cricket "}}, want: true},
+		{name: "other query", webhook: models.Webhook{Queries: []string{"football"}}},
+		{name: "channel", webhook: models.Webhook{ChannelIDs: []string{"ucespn"}}, want: true},
+		{name: "other channel", webhook: models.Webhook{ChannelIDs: []string{"UCother"}}},
+		// Keywords match whole words and phrases in the title or description
+		{name: "keyword phrase", webhook: models.Webhook{Keywords: []string{"world cup"}}, want: true},
+		{name: "keyword in description", webhook: models.Webhook{Keywords: []string{"best moments"}}, want: true},
+		{name: "partial word", webhook: models.Webhook{Keywords: []string{"high"}}},
+		{name: "all filters must pass", webhook: models.Webhook{Queries: []string{"cricket"}, Keywords: []string{"tennis"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WebhookMatches(&tt.webhook, video); got != tt.want {
+				t.Errorf("WebhookMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
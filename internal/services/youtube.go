@@ -2,14 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 
+	"fampay-youtube-api/internal/config"
 	"fampay-youtube-api/internal/models"
 )
 
@@ -22,10 +25,16 @@ type YouTubeService struct {
 	keyQuotaStatus     map[int]time.Time
 	regionCode         string
 	relevanceLanguage  string
+	safeSearch         string
+	querySafeSearch    map[string]string
 }
 
-func NewYouTubeService(apiKeys []string, searchQueries []string, maxResultsPerQuery int, regionCode, relevanceLanguage string) *YouTubeService {
+func NewYouTubeService(apiKeys []string, searchQueries []string, maxResultsPerQuery int, regionCode, relevanceLanguage, safeSearch string, querySafeSearch map[string]string) *YouTubeService {
 	log.Printf("Initializing YouTube service with %d API keys and %d search queries", len(apiKeys), len(searchQueries))
+	if !validSafeSearch(safeSearch) {
+		log.Printf("⚠️ Invalid safe-search level %q, using 'moderate'", safeSearch)
+		safeSearch = "moderate"
+	}
 	return &YouTubeService{
 		apiKeys:            apiKeys,
 		searchQueries:      searchQueries,
@@ -33,63 +42,54 @@ func NewYouTubeService(apiKeys []string, searchQueries []string, maxResultsPerQu
 		keyQuotaStatus:     make(map[int]time.Time),
 		regionCode:         regionCode,
 		relevanceLanguage:  relevanceLanguage,
+		safeSearch:         safeSearch,
+		querySafeSearch:    querySafeSearch,
 	}
 }
 
-// FamPay Requirement: Fetch latest videos for predefined search queries
-func (ys *YouTubeService) FetchLatestVideosForAllQueries(publishedAfter time.Time) ([]*models.Video, error) {
-	var allVideos []*models.Video
-
-	// FamPay Requirement: Fetch for ALL predefined search queries
-	for _, query := range ys.searchQueries {
-		log.Printf("🔍 Fetching latest videos for query: '%s'", query)
-
-		videos, err := ys.fetchLatestVideosForQuery(query, publishedAfter)
-		if err != nil {
-			log.Printf("❌ Error fetching videos for query '%s': %v", query, err)
-			// Continue with other queries even if one fails
-			continue
-		}
-
-		allVideos = append(allVideos, videos...)
-		log.Printf("✅ Found %d videos for '%s'", len(videos), query)
-	}
+// NewYouTubeServiceFromConfig creates a YouTube service from the YouTube configuration
+func NewYouTubeServiceFromConfig(youtubeConfig config.YouTubeConfig) *YouTubeService {
+	return NewYouTubeService(
+		youtubeConfig.APIKeys,
+		youtubeConfig.SearchQueries,
+		youtubeConfig.MaxResultsPerQuery,
+		youtubeConfig.RegionCode,
+		youtubeConfig.RelevanceLanguage,
+		youtubeConfig.SafeSearch,
+		youtubeConfig.QuerySafeSearch,
+	)
+}
 
-	log.Printf("📊 Total videos fetched: %d from %d queries", len(allVideos), len(ys.searchQueries))
-	return allVideos, nil
+// FamPay Requirement: Fetch latest videos for a predefined search query
+func (ys *YouTubeService) FetchLatestVideosForQuery(query string, publishedAfter time.Time) ([]*models.Video, error) {
+	log.Printf("🔍 Fetching latest videos for query: '%s'", query)
+	return ys.fetchLatestVideosForQuery(query, publishedAfter)
 }
 
 func (ys *YouTubeService) fetchLatestVideosForQuery(query string, publishedAfter time.Time) ([]*models.Video, error) {
-	service, err := ys.getYouTubeService()
+	var response *youtube.SearchListResponse
+	var apiCallDuration time.Duration
+
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		// FamPay Requirement: YouTube API call with proper parameters
+		call := service.Search.List([]string{"snippet"}).
+			Q(query).
+			Type("video").                                       // Only videos
+			Order("date").                                       // Latest first (FamPay requirement)
+			PublishedAfter(publishedAfter.Format(time.RFC3339)). // Latest videos only
+			MaxResults(int64(ys.maxResultsPerQuery)).            // Configurable results
+			RegionCode(ys.regionCode).                           // Regional content
+			RelevanceLanguage(ys.relevanceLanguage).             // Language preference
+			SafeSearch(ys.safeSearchFor(query))                  // Safe content (configurable per query)
+
+		startTime := time.Now()
+		var err error
+		response, err = call.Do()
+		apiCallDuration = time.Since(startTime)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create YouTube service: %w", err)
-	}
-
-	// FamPay Requirement: YouTube API call with proper parameters
-	call := service.Search.List([]string{"snippet"}).
-		Q(query).
-		Type("video").                                       // Only videos
-		Order("date").                                       // Latest first (FamPay requirement)
-		PublishedAfter(publishedAfter.Format(time.RFC3339)). // Latest videos only
-		MaxResults(int64(ys.maxResultsPerQuery)).            // Configurable results
-		RegionCode(ys.regionCode).                           // Regional content
-		RelevanceLanguage(ys.relevanceLanguage).             // Language preference
-		SafeSearch("moderate")                               // Safe content
-
-	startTime := time.Now()
-	response, err := call.Do()
-	apiCallDuration := time.Since(startTime)
-
-	if err != nil {
-		// FamPay Bonus: Multiple API key support - rotate on failure
-		log.Printf("🔄 API key %d quota exhausted, rotating to next key...", ys.currentKeyIdx+1)
-		ys.markKeyAsFailed(ys.currentKeyIdx)
-
-		if ys.rotateToNextWorkingKey() {
-			log.Printf("✅ Retrying with API key %d", ys.currentKeyIdx+1)
-			return ys.fetchLatestVideosForQuery(query, publishedAfter)
-		}
-		return nil, fmt.Errorf("all API keys exhausted: %w", err)
+		return nil, fmt.Errorf("failed to search videos: %w", err)
 	}
 
 	// FamPay Requirement: Extract and store required video fields
@@ -111,14 +111,83 @@ func (ys *YouTubeService) fetchLatestVideosForQuery(query string, publishedAfter
 				High:    getThumbnailURL(item.Snippet.Thumbnails.High),
 			},
 		}
+		video.LiveBroadcastContent = item.Snippet.LiveBroadcastContent // none, live or upcoming
 		videos = append(videos, video)
 	}
 
+	// Enrich with kid-safety signals; videos are still stored if this fails
+	if err := ys.enrichVideos(videos); err != nil {
+		log.Printf("⚠️ Could not enrich videos for '%s': %v", query, err)
+	}
+
 	log.Printf("📹 Fetched %d videos for '%s' in %v (API quota: 100 units used, Key: %d)",
-		len(videos), query, apiCallDuration, ys.currentKeyIdx+1)
+		len(videos), query, apiCallDuration, ys.currentKey()+1)
 	return videos, nil
 }
 
+// Error reasons meaning the current API key can't serve requests until
+// its daily quota resets
+var exhaustedKeyReasons = map[string]bool{
+	"quotaExceeded":      true,
+	"dailyLimitExceeded": true,
+	"keyInvalid":         true,
+}
+
+// withAPIKey runs call with a client for the current API key. When the key
+// is out of quota it is marked as exhausted and the call is retried on the
+// next working key; other errors are returned unchanged. Every worker
+// shares one YouTubeService, so a rotation made by one applies to all.
+func (ys *YouTubeService) withAPIKey(call func(service *youtube.Service) error) error {
+	for {
+		keyIdx := ys.currentKey()
+		service, err := ys.serviceForKey(keyIdx)
+		if err != nil {
+			return fmt.Errorf("failed to create YouTube service: %w", err)
+		}
+
+		err = call(service)
+		if err == nil || !isKeyExhausted(err) {
+			return err
+		}
+
+		// FamPay Bonus: Multiple API key support - rotate on quota errors
+		log.Printf("🔄 API key %d quota exhausted, rotating to next key...", keyIdx+1)
+		ys.markKeyAsFailed(keyIdx)
+		if !ys.rotateAfterFailure(keyIdx) {
+			return fmt.Errorf("all API keys exhausted: %w", err)
+		}
+		log.Printf("✅ Retrying with API key %d", ys.currentKey()+1)
+	}
+}
+
+func isKeyExhausted(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if exhaustedKeyReasons[item.Reason] {
+			return true
+		}
+	}
+	return false
+}
+
+// rotateAfterFailure moves off a failed key unless a concurrent call has
+// already done so
+func (ys *YouTubeService) rotateAfterFailure(keyIdx int) bool {
+	if ys.currentKey() != keyIdx {
+		return true
+	}
+	return ys.rotateToNextWorkingKey()
+}
+
+func (ys *YouTubeService) currentKey() int {
+	ys.mutex.RLock()
+	defer ys.mutex.RUnlock()
+	return ys.currentKeyIdx
+}
+
 // FamPay Bonus: Multiple API key support
 func (ys *YouTubeService) markKeyAsFailed(keyIndex int) {
 	ys.mutex.Lock()
@@ -165,11 +234,6 @@ func (ys *YouTubeService) rotateToNextWorkingKey() bool {
 
 // For search functionality (bonus feature)
 func (ys *YouTubeService) SearchYouTubeLive(query string, maxResults int, sortBy string) ([]*models.Video, error) {
-	service, err := ys.getYouTubeService()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create YouTube service: %w", err)
-	}
-
 	// Convert sortBy to YouTube API order - Fixed the empty order issue
 	var order string
 	switch sortBy {
@@ -185,16 +249,21 @@ func (ys *YouTubeService) SearchYouTubeLive(query string, maxResults int, sortBy
 		order = "relevance" // Safe default
 	}
 
-	call := service.Search.List([]string{"snippet"}).
-		Q(query).
-		Type("video").
-		Order(order).
-		MaxResults(int64(maxResults)).
-		RegionCode(ys.regionCode).
-		RelevanceLanguage(ys.relevanceLanguage).
-		SafeSearch("moderate")
-
-	response, err := call.Do()
+	var response *youtube.SearchListResponse
+	err := ys.withAPIKey(func(service *youtube.Service) error {
+		call := service.Search.List([]string{"snippet"}).
+			Q(query).
+			Type("video").
+			Order(order).
+			MaxResults(int64(maxResults)).
+			RegionCode(ys.regionCode).
+			RelevanceLanguage(ys.relevanceLanguage).
+			SafeSearch(ys.safeSearch)
+
+		var err error
+		response, err = call.Do()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("YouTube search failed: %w", err)
 	}
@@ -240,9 +309,9 @@ func (ys *YouTubeService) GetAPIKeyStatus() map[string]interface{} {
 	return status
 }
 
-func (ys *YouTubeService) getYouTubeService() (*youtube.Service, error) {
+func (ys *YouTubeService) serviceForKey(keyIdx int) (*youtube.Service, error) {
 	ys.mutex.RLock()
-	apiKey := ys.apiKeys[ys.currentKeyIdx]
+	apiKey := ys.apiKeys[keyIdx]
 	ys.mutex.RUnlock()
 
 	ctx := context.Background()
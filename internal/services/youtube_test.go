@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+func quotaError(reason string) error {
+	return fmt.Errorf("failed to fetch: %w", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: reason}}})
+}
+
+func TestIsKeyExhausted(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{quotaError("quotaExceeded"), true},
+		{quotaError("dailyLimitExceeded"), true},
+		{quotaError("keyInvalid"), true},
+		{quotaError("commentsDisabled"), false},
+		{&googleapi.Error{Code: 400, Errors: []googleapi.ErrorItem{{Reason: "invalidParameter"}}}, false},
+		{errors.New("connection reset by peer"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isKeyExhausted(tt.err); got != tt.want {
+			t.Errorf("isKeyExhausted(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWithAPIKeyRotation(t *testing.T) {
+	newService := func() *YouTubeService {
+		return NewYouTubeService([]string{"key1", "key2", "key3"}, nil, 10, "IN", "en", "moderate", nil)
+	}
+
+	tests := []struct {
+		name      string
+		failures  map[int]error // Error returned for each key index
+		wantKeys  []int         // Keys tried, in order
+		wantKey   int           // Current key afterwards
+		wantError string
+	}{
+		{name: "working key", wantKeys: []int{0}},
+		{
+			name:     "rotates past exhausted keys",
+			failures: map[int]error{0: quotaError("quotaExceeded"), 1: quotaError("dailyLimitExceeded")},
+			wantKeys: []int{0, 1, 2},
+			wantKey:  2,
+		},
+		{
+			name:      "all keys exhausted",
+			failures:  map[int]error{0: quotaError("quotaExceeded"), 1: quotaError("quotaExceeded"), 2: quotaError("quotaExceeded")},
+			wantKeys:  []int{0, 1, 2},
+			wantError: "all API keys exhausted",
+		},
+		{
+			// Errors unrelated to the key don't burn the remaining keys
+			name:      "other errors",
+			failures:  map[int]error{0: errors.New("connection reset by peer")},
+			wantKeys:  []int{0},
+			wantError: "connection reset by peer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ys := newService()
+			var keys []int
+			err := ys.withAPIKey(func(service *youtube.Service) error {
+				key := ys.currentKey()
+				keys = append(keys, key)
+				return tt.failures[key]
+			})
+
+			if tt.wantError == "" && err != nil {
+				t.Errorf("withAPIKey() error = %v", err)
+			}
+			if tt.wantError != "" && (err == nil || !strings.Contains(err.Error(), tt.wantError)) {
+				t.Errorf("withAPIKey() error = %v, want %q", err, tt.wantError)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("tried keys %v, want %v", keys, tt.wantKeys)
+			}
+			if tt.wantError == "" && ys.currentKey() != tt.wantKey {
+				t.Errorf("current key = %d, want %d", ys.currentKey(), tt.wantKey)
+			}
+		})
+	}
+
+	// A second caller starts on the key the first one rotated to
+	ys := newService()
+	ys.withAPIKey(func(service *youtube.Service) error {
+		if ys.currentKey() == 0 {
+			return quotaError("quotaExceeded")
+		}
+		return nil
+	})
+	var used int
+	ys.withAPIKey(func(service *youtube.Service) error {
+		used = ys.currentKey()
+		return nil
+	})
+	if used != 1 {
+		t.Errorf("second call used key %d, want 1", used)
+	}
+}
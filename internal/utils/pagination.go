@@ -1,18 +1,37 @@
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 type PaginatedResponse struct {
-	Results  interface{} `json:"results"`
-	Count    int64       `json:"count"`
-	Next     *string     `json:"next"`
-	Previous *string     `json:"previous"`
+	Results        interface{} `json:"results"`
+	Count          *int64      `json:"count,omitempty"` // Not counted for cursor requests
+	Next           *string     `json:"next"`
+	Previous       *string     `json:"previous"`
+	NextCursor     string      `json:"next_cursor,omitempty"`
+	PreviousCursor string      `json:"previous_cursor,omitempty"`
+	Facets         interface{} `json:"facets,omitempty"`
+	Fuzzy          bool        `json:"fuzzy,omitempty"`      // Results are approximate (typo-tolerant) matches
+	Suggestion     string      `json:"suggestion,omitempty"` // Spelling-corrected query that has results ("did you mean")
 }
 
 func NewPaginatedResponse(data interface{}, total int64, page, pageSize int) *PaginatedResponse {
+	return NewPaginatedResponseForPath(data, total, page, pageSize, "/api/videos")
+}
+
+// NewPaginatedResponseForPath builds next/previous links for endpoints other than /api/videos
+func NewPaginatedResponseForPath(data interface{}, total int64, page, pageSize int, path string) *PaginatedResponse {
+	return NewPaginatedResponseForQuery(data, total, page, pageSize, path, nil)
+}
+
+// NewPaginatedResponseForQuery keeps the request's other parameters
+// (search query, filters, sort) in the next/previous links
+func NewPaginatedResponseForQuery(data interface{}, total int64, page, pageSize int, path string, query url.Values) *PaginatedResponse {
 	response := &PaginatedResponse{
 		Results: data,
-		Count:   total,
+		Count:   &total,
 	}
 
 	// Calculate next and previous URLs
@@ -20,19 +39,64 @@ func NewPaginatedResponse(data interface{}, total int64, page, pageSize int) *Pa
 
 	if page < int(totalPages) {
 		nextPage := page + 1
-		nextURL := generatePageURL(nextPage, pageSize)
+		nextURL := generatePageURL(path, query, nextPage, pageSize)
 		response.Next = &nextURL
 	}
 
 	if page > 1 {
 		prevPage := page - 1
-		prevURL := generatePageURL(prevPage, pageSize)
+		prevURL := generatePageURL(path, query, prevPage, pageSize)
 		response.Previous = &prevURL
 	}
 
 	return response
 }
 
-func generatePageURL(page, pageSize int) string {
-	return fmt.Sprintf("/api/videos?page=%d&page_size=%d", page, pageSize)
+// NewCursorPaginatedResponse builds next/previous links carrying cursor
+// tokens. query holds the request's other parameters (filters, sort), which
+// the links keep.
+func NewCursorPaginatedResponse(data interface{}, path string, query url.Values, nextCursor, previousCursor string) *PaginatedResponse {
+	response := &PaginatedResponse{
+		Results:        data,
+		NextCursor:     nextCursor,
+		PreviousCursor: previousCursor,
+	}
+
+	if nextCursor != "" {
+		nextURL := generateCursorURL(path, query, nextCursor)
+		response.Next = &nextURL
+	}
+	if previousCursor != "" {
+		prevURL := generateCursorURL(path, query, previousCursor)
+		response.Previous = &prevURL
+	}
+
+	return response
+}
+
+func generatePageURL(path string, query url.Values, page, pageSize int) string {
+	if len(query) == 0 {
+		return fmt.Sprintf("%s?page=%d&page_size=%d", path, page, pageSize)
+	}
+
+	params := url.Values{}
+	for key, values := range query {
+		if key != "page" && key != "page_size" && key != "cursor" {
+			params[key] = values
+		}
+	}
+	params.Set("page", fmt.Sprint(page))
+	params.Set("page_size", fmt.Sprint(pageSize))
+	return path + "?" + params.Encode()
+}
+
+func generateCursorURL(path string, query url.Values, cursor string) string {
+	params := url.Values{}
+	for key, values := range query {
+		if key != "page" && key != "cursor" {
+			params[key] = values
+		}
+	}
+	params.Set("cursor", cursor)
+	return path + "?" + params.Encode()
 }
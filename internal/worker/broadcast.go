@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"log"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// Upper bound of broadcasts re-checked per cycle (1 quota unit per 50)
+const broadcastCheckLimit = 500
+
+// BroadcastTracker moves live streams and premieres through
+// upcoming → live → completed as YouTube reports their progress
+type BroadcastTracker struct {
+	videoRepo      *repository.VideoRepository
+	youtubeService *services.YouTubeService
+	checkInterval  time.Duration
+	stopChan       chan struct{}
+}
+
+func NewBroadcastTracker(videoRepo *repository.VideoRepository, youtubeService *services.YouTubeService, youtubeConfig config.YouTubeConfig) *BroadcastTracker {
+	return &BroadcastTracker{
+		videoRepo:      videoRepo,
+		youtubeService: youtubeService,
+		checkInterval:  time.Duration(youtubeConfig.BroadcastCheckInterval) * time.Second,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+func (bt *BroadcastTracker) Start() {
+	log.Printf("📡 Starting broadcast tracker (every %v)", bt.checkInterval)
+
+	ticker := time.NewTicker(bt.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			bt.checkBroadcasts()
+		case <-bt.stopChan:
+			log.Println("Broadcast tracker stopped")
+			return
+		}
+	}
+}
+
+func (bt *BroadcastTracker) Stop() {
+	close(bt.stopChan)
+}
+
+func (bt *BroadcastTracker) checkBroadcasts() {
+	videos, err := bt.videoRepo.GetActiveBroadcasts(broadcastCheckLimit)
+	if err != nil {
+		log.Printf("❌ Error loading active broadcasts: %v", err)
+		return
+	}
+	if len(videos) == 0 {
+		return
+	}
+
+	updates, err := bt.youtubeService.FetchBroadcasts(videos)
+	if err != nil {
+		log.Printf("❌ Error fetching broadcast details: %v", err)
+		return
+	}
+
+	transitions := 0
+	for _, video := range videos {
+		update, ok := updates[video.VideoID]
+		if !ok {
+			// Deleted, made private or cancelled before going live; there
+			// is no recording left to classify
+			update = &services.BroadcastUpdate{
+				Broadcast: &models.Broadcast{
+					State:          models.BroadcastCompleted,
+					ScheduledStart: video.Broadcast.ScheduledStart,
+					ActualStart:    video.Broadcast.ActualStart,
+					CheckedAt:      time.Now(),
+				},
+				DurationSeconds: video.DurationSeconds,
+			}
+		}
+		broadcast := update.Broadcast
+
+		if broadcast.State != video.Broadcast.State {
+			log.Printf("📡 Broadcast %s ('%s') is now %s", video.VideoID, video.Title, broadcast.State)
+			transitions++
+		}
+
+		if broadcast.State == models.BroadcastCompleted {
+			err = bt.videoRepo.CompleteBroadcast(video.VideoID, broadcast, update.Format, update.DurationSeconds)
+		} else {
+			err = bt.videoRepo.UpdateBroadcast(video.VideoID, broadcast)
+		}
+		if err != nil {
+			log.Printf("⚠️ Error updating broadcast %s: %v", video.VideoID, err)
+		}
+	}
+
+	log.Printf("📡 Checked %d broadcasts, %d changed state", len(videos), transitions)
+}
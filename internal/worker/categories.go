@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"log"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// CategoryRefresher ingests the video category catalog of each configured
+// region and resolves category names on stored videos
+type CategoryRefresher struct {
+	categoryRepo    *repository.CategoryRepository
+	videoRepo       *repository.VideoRepository
+	youtubeService  *services.YouTubeService
+	regions         []string
+	videoRegion     string // Region whose names are used for stored videos
+	refreshInterval time.Duration
+	stopChan        chan struct{}
+}
+
+func NewCategoryRefresher(categoryRepo *repository.CategoryRepository, videoRepo *repository.VideoRepository, youtubeService *services.YouTubeService, youtubeConfig config.YouTubeConfig) *CategoryRefresher {
+	return &CategoryRefresher{
+		categoryRepo:    categoryRepo,
+		videoRepo:       videoRepo,
+		youtubeService:  youtubeService,
+		regions:         youtubeConfig.CategoryRegions,
+		videoRegion:     youtubeConfig.RegionCode,
+		refreshInterval: time.Duration(youtubeConfig.CategoryRefreshInterval) * time.Second,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+func (cr *CategoryRefresher) Start() {
+	log.Printf("🏷️ Starting category refresher: regions %v (every %v)", cr.regions, cr.refreshInterval)
+
+	cr.refreshCategories()
+
+	ticker := time.NewTicker(cr.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cr.refreshCategories()
+		case <-cr.stopChan:
+			log.Println("Category refresher stopped")
+			return
+		}
+	}
+}
+
+func (cr *CategoryRefresher) Stop() {
+	close(cr.stopChan)
+}
+
+func (cr *CategoryRefresher) refreshCategories() {
+	for _, region := range cr.regions {
+		categories, err := cr.youtubeService.FetchVideoCategories(region)
+		if err != nil {
+			log.Printf("❌ Error fetching video categories for %s: %v", region, err)
+			continue
+		}
+		if err := cr.categoryRepo.UpsertMany(categories); err != nil {
+			log.Printf("❌ Error storing video categories for %s: %v", region, err)
+			continue
+		}
+		log.Printf("🏷️ Stored %d video categories for %s", len(categories), region)
+	}
+
+	// Backfill names on videos stored before the catalog was available
+	names, err := cr.categoryRepo.NameMap(cr.videoRegion)
+	if err != nil {
+		log.Printf("❌ Error loading video categories: %v", err)
+		return
+	}
+	updated, err := cr.videoRepo.SetCategoryNames(names)
+	if err != nil {
+		log.Printf("❌ Error resolving video category names: %v", err)
+		return
+	}
+	if updated > 0 {
+		log.Printf("🏷️ Resolved category names on %d videos", updated)
+	}
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"log"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// Upper bound of channels refreshed per cycle (1 quota unit per 50)
+const channelRefreshLimit = 500
+
+// ChannelRefresher keeps the channels collection populated and fresh for
+// every channel that appears on a stored video
+type ChannelRefresher struct {
+	channelRepo     *repository.ChannelRepository
+	youtubeService  *services.YouTubeService
+	refreshInterval time.Duration
+	staleAfter      time.Duration
+	stopChan        chan struct{}
+}
+
+func NewChannelRefresher(channelRepo *repository.ChannelRepository, youtubeService *services.YouTubeService, youtubeConfig config.YouTubeConfig) *ChannelRefresher {
+	return &ChannelRefresher{
+		channelRepo:     channelRepo,
+		youtubeService:  youtubeService,
+		refreshInterval: time.Duration(youtubeConfig.ChannelRefreshInterval) * time.Second,
+		staleAfter:      time.Duration(youtubeConfig.ChannelStaleAfter) * time.Hour,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+func (cr *ChannelRefresher) Start() {
+	log.Printf("📺 Starting channel refresher (every %v, stale after %v)", cr.refreshInterval, cr.staleAfter)
+
+	cr.refreshChannels()
+
+	ticker := time.NewTicker(cr.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cr.refreshChannels()
+		case <-cr.stopChan:
+			log.Println("Channel refresher stopped")
+			return
+		}
+	}
+}
+
+func (cr *ChannelRefresher) Stop() {
+	close(cr.stopChan)
+}
+
+func (cr *ChannelRefresher) refreshChannels() {
+	ids, err := cr.channelRepo.StaleChannelIDs(time.Now().Add(-cr.staleAfter), channelRefreshLimit)
+	if err != nil {
+		log.Printf("❌ Error finding stale channels: %v", err)
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	channels, err := cr.youtubeService.FetchChannels(ids)
+	if err != nil {
+		log.Printf("❌ Error fetching channels: %v", err)
+		return
+	}
+
+	if err := cr.channelRepo.UpsertMany(channels); err != nil {
+		log.Printf("❌ Error storing channels: %v", err)
+		return
+	}
+
+	log.Printf("📺 Refreshed %d/%d channels", len(channels), len(ids))
+}
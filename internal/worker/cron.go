@@ -0,0 +1,207 @@
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard 5-field cron expression
+// (minute hour day-of-month month day-of-week).
+type CronSchedule struct {
+	expression string
+	minutes    uint64
+	hours      uint64
+	days       uint64
+	months     uint64
+	weekdays   uint64
+	anyDay     bool
+	anyWeekday bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField  = cronField{name: "minute", min: 0, max: 59}
+	hourField    = cronField{name: "hour", min: 0, max: 23}
+	dayField     = cronField{name: "day of month", min: 1, max: 31}
+	monthField   = cronField{name: "month", min: 1, max: 12, names: map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}}
+	weekdayField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression such as "*/15 * * * *" or "@hourly"
+func ParseCron(expression string) (*CronSchedule, error) {
+	expr := strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expression, len(fields))
+	}
+
+	schedule := &CronSchedule{expression: strings.TrimSpace(expression)}
+	var err error
+
+	if schedule.minutes, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = dayField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays, err = weekdayField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// Both 0 and 7 mean Sunday
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+
+	schedule.anyDay = fields[2] == "*" || fields[2] == "?"
+	schedule.anyWeekday = fields[4] == "*" || fields[4] == "?"
+
+	return schedule, nil
+}
+
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(value, ",") {
+		if part == "" {
+			return 0, fmt.Errorf("invalid %s field %q: empty list entry", f.name, value)
+		}
+
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangePart = part[:idx]
+			parsedStep, err := strconv.Atoi(part[idx+1:])
+			if err != nil || parsedStep < 1 {
+				return 0, fmt.Errorf("invalid %s step in %q", f.name, part)
+			}
+			step = parsedStep
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			// Full range
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangePart); err != nil {
+				return 0, err
+			}
+			end = start
+			// "5/10" means starting at 5 through the end of the range
+			if strings.Contains(part, "/") {
+				end = f.max
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(token string) (int, error) {
+	if n, ok := f.names[strings.ToLower(token)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", f.name, token)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// Next returns the first matching time strictly after t
+func (cs *CronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if cs.months&(1<<uint(next.Month())) == 0 {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !cs.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if cs.hours&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if cs.minutes&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	// Expressions like "0 0 31 2 *" never fire
+	return time.Time{}
+}
+
+// Standard cron semantics: when both day fields are restricted, either may match
+func (cs *CronSchedule) matchesDay(t time.Time) bool {
+	dayMatch := cs.days&(1<<uint(t.Day())) != 0
+	weekdayMatch := cs.weekdays&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case cs.anyDay && cs.anyWeekday:
+		return true
+	case cs.anyDay:
+		return weekdayMatch
+	case cs.anyWeekday:
+		return dayMatch
+	default:
+		return dayMatch || weekdayMatch
+	}
+}
+
+func (cs *CronSchedule) String() string {
+	return cs.expression
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{"* * * *", "expected 5 fields"},
+		{"* * * * * *", "expected 5 fields"},
+		{"60 * * * *", "minute value 60 out of range"},
+		{"* 24 * * *", "hour value 24 out of range"},
+		{"* * 0 * *", "day of month value 0 out of range"},
+		{"* * * 13 *", "month value 13 out of range"},
+		{"* * * * 8", "day of week value 8 out of range"},
+		{"*/0 * * * *", "invalid minute step"},
+		{"10-5 * * * *", "invalid minute range"},
+		{"1,,2 * * * *", "empty list entry"},
+		{"* * * foo *", "invalid month value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := ParseCron(tt.expression)
+			if err == nil {
+				t.Fatalf("ParseCron(%q) succeeded, want error containing %q", tt.expression, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCron(%q) error = %q, want it to contain %q", tt.expression, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday
+	base := time.Date(2026, 1, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		want       time.Time
+	}{
+		{"every minute", "* * * * *", base, time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC)},
+		{"strictly after", "8 * * * *", time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC), time.Date(2026, 1, 14, 11, 8, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", base, time.Date(2026, 1, 14, 10, 15, 0, 0, time.UTC)},
+		{"step from offset", "5/20 * * * *", base, time.Date(2026, 1, 14, 10, 25, 0, 0, time.UTC)},
+		{"range and list", "0 9-11,18 * * *", base, time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{"hourly descriptor", "@hourly", base, time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{"daily rolls over day", "@daily", base, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"weekday name", "30 6 * * fri", base, time.Date(2026, 1, 16, 6, 30, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", base, time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{"month name rolls over year", "0 0 1 jan *", base, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 12 29 2 *", base, time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		// Either restricted day field may match: the 20th or the next Monday
+		{"day or weekday", "0 0 20 * mon", base, time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"never fires", "0 0 31 2 *", base, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expression, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleString(t *testing.T) {
+	schedule, err := ParseCron("  @Hourly ")
+	if err != nil {
+		t.Fatalf("ParseCron: %v", err)
+	}
+	if got := schedule.String(); got != "@Hourly" {
+		t.Errorf("String() = %q, want %q", got, "@Hourly")
+	}
+}
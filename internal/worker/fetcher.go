@@ -5,36 +5,61 @@ import (
 	"time"
 
 	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
 	"fampay-youtube-api/internal/repository"
 	"fampay-youtube-api/internal/services"
 )
 
+// How often the fetcher checks which query schedules are due
+const scheduleTick = time.Second
+
 type VideoFetcher struct {
 	videoRepo      *repository.VideoRepository
+	commentRepo    *repository.CommentRepository
+	transcriptRepo *repository.TranscriptRepository
+	categoryRepo   *repository.CategoryRepository
+	captionSource  services.CaptionSource
 	youtubeService *services.YouTubeService
-	fetchInterval  time.Duration
+	scheduler      *QueryScheduler
+	filterEngine   *services.FilterEngine
+	synonyms       *services.SynonymService
+	listeners      []IngestListener
 	stopChan       chan struct{}
 	config         config.YouTubeConfig
 }
 
-func NewVideoFetcher(videoRepo *repository.VideoRepository, youtubeConfig config.YouTubeConfig) *VideoFetcher {
-	youtubeService := services.NewYouTubeService(
-		youtubeConfig.APIKeys,
-		youtubeConfig.SearchQueries,
-		youtubeConfig.MaxResultsPerQuery,
-		youtubeConfig.RegionCode,
-		youtubeConfig.RelevanceLanguage,
-	)
+// IngestListener is notified after the fetcher stores a new video
+type IngestListener interface {
+	VideoStored(video *models.Video) error
+}
+
+func NewVideoFetcher(videoRepo *repository.VideoRepository, commentRepo *repository.CommentRepository, transcriptRepo *repository.TranscriptRepository, categoryRepo *repository.CategoryRepository, filterEngine *services.FilterEngine, synonyms *services.SynonymService, youtubeService *services.YouTubeService, youtubeConfig config.YouTubeConfig) *VideoFetcher {
+	// Local caption files replace YouTube when a fixture directory is configured
+	var captionSource services.CaptionSource = services.NewYouTubeCaptionSource(youtubeService)
+	if youtubeConfig.CaptionFixtureDir != "" {
+		captionSource = services.NewFixtureCaptionSource(youtubeConfig.CaptionFixtureDir)
+	}
 
 	return &VideoFetcher{
 		videoRepo:      videoRepo,
+		commentRepo:    commentRepo,
+		transcriptRepo: transcriptRepo,
+		categoryRepo:   categoryRepo,
+		captionSource:  captionSource,
 		youtubeService: youtubeService,
-		fetchInterval:  time.Duration(youtubeConfig.FetchInterval) * time.Second, // EXACTLY as per config (10 seconds)
+		scheduler:      NewQueryScheduler(youtubeConfig),
+		filterEngine:   filterEngine,
+		synonyms:       synonyms,
 		stopChan:       make(chan struct{}),
 		config:         youtubeConfig,
 	}
 }
 
+// AddListener registers a listener for newly stored videos
+func (vf *VideoFetcher) AddListener(listener IngestListener) {
+	vf.listeners = append(vf.listeners, listener)
+}
+
 func (vf *VideoFetcher) Start() {
 	log.Printf("🚀 Starting video fetcher (FamPay Requirements Compliance):")
 	log.Printf("📋 Search queries: %v", vf.config.SearchQueries)
@@ -42,12 +67,17 @@ func (vf *VideoFetcher) Start() {
 	log.Printf("⏰ Fetch interval: %d seconds (as per requirements)", vf.config.FetchInterval)
 	log.Printf("📊 Max results per query: %d", vf.config.MaxResultsPerQuery)
 	log.Printf("🌍 Region: %s, Language: %s", vf.config.RegionCode, vf.config.RelevanceLanguage)
+	for _, schedule := range vf.scheduler.Snapshot() {
+		if schedule.Mode != models.ScheduleModeInterval {
+			log.Printf("🗓️ Query '%s' scheduled in %s mode %s", schedule.Query, schedule.Mode, schedule.Expression)
+		}
+	}
 
-	// Initial fetch
+	// Initial fetch (interval and adaptive queries are due on startup)
 	vf.fetchAndStore()
 
-	// FamPay Requirement: Continuous background fetching at specified interval
-	ticker := time.NewTicker(vf.fetchInterval)
+	// FamPay Requirement: Continuous background fetching, each query on its own schedule
+	ticker := time.NewTicker(scheduleTick)
 	defer ticker.Stop()
 
 	for {
@@ -65,31 +95,45 @@ func (vf *VideoFetcher) Stop() {
 	close(vf.stopChan)
 }
 
+// Schedules returns the current schedule and next run of every query
+func (vf *VideoFetcher) Schedules() []models.QuerySchedule {
+	return vf.scheduler.Snapshot()
+}
+
 func (vf *VideoFetcher) fetchAndStore() {
 	startTime := time.Now()
 
-	// Get last published date from database to avoid duplicates
-	lastVideo, err := vf.videoRepo.GetLatest()
-
-	// FamPay Requirement: Fetch latest videos - use reasonable time window
-	publishedAfter := time.Now().Add(-2 * time.Hour)
-	if err == nil && lastVideo != nil && !lastVideo.PublishedAt.IsZero() {
-		// Use last published date but ensure we don't miss videos
-		publishedAfter = lastVideo.PublishedAt.Add(-5 * time.Minute)
+	// Only fetch queries whose schedule is due
+	queries := vf.scheduler.Due(startTime)
+	if len(queries) == 0 {
+		return
 	}
 
-	log.Printf("🔍 Fetching latest videos published after: %s", publishedAfter.Format("2006-01-02 15:04:05"))
+	var videos []*models.Video
+	storedPerQuery := make(map[string]int, len(queries))
+	defer func() {
+		for _, query := range queries {
+			vf.scheduler.Record(query, storedPerQuery[query], time.Now())
+		}
+	}()
+
+	for _, query := range queries {
+		publishedAfter := vf.publishedAfter(query)
+		log.Printf("🔍 Fetching latest '%s' videos published after: %s", query, publishedAfter.Format("2006-01-02 15:04:05"))
 
-	// FamPay Requirement: Fetch for ALL predefined search queries
-	videos, err := vf.youtubeService.FetchLatestVideosForAllQueries(publishedAfter)
-	if err != nil {
-		log.Printf("❌ Error fetching videos: %v", err)
+		queryVideos, err := vf.youtubeService.FetchLatestVideosForQuery(query, publishedAfter)
+		if err != nil {
+			log.Printf("❌ Error fetching videos for query '%s': %v", query, err)
 
-		// Log API key status for debugging - FamPay Bonus: Multiple API key support
-		status := vf.youtubeService.GetAPIKeyStatus()
-		log.Printf("🔑 API Status: %d/%d keys working, next retry in %v",
-			status["working_keys"], status["total_keys"], vf.fetchInterval)
-		return
+			// Log API key status for debugging - FamPay Bonus: Multiple API key support
+			status := vf.youtubeService.GetAPIKeyStatus()
+			log.Printf("🔑 API Status: %d/%d keys working", status["working_keys"], status["total_keys"])
+			// Continue with other queries even if one fails
+			continue
+		}
+
+		log.Printf("✅ Found %d videos for '%s'", len(queryVideos), query)
+		videos = append(videos, queryVideos...)
 	}
 
 	if len(videos) == 0 {
@@ -97,9 +141,21 @@ func (vf *VideoFetcher) fetchAndStore() {
 		return
 	}
 
+	// Pick up filter rule changes made through the admin API
+	if err := vf.filterEngine.Reload(); err != nil {
+		log.Printf("⚠️ Error loading filter rules, keeping previous rules: %v", err)
+	}
+
+	// Category names from the catalog (videos stored without one are backfilled by the category refresher)
+	categoryNames, err := vf.categoryRepo.NameMap(vf.config.RegionCode)
+	if err != nil {
+		log.Printf("⚠️ Error loading video categories: %v", err)
+	}
+
 	// FamPay Requirement: Store video data in database
 	stored := 0
 	skipped := 0
+	filtered := 0
 	errors := 0
 
 	for _, video := range videos {
@@ -113,6 +169,16 @@ func (vf *VideoFetcher) fetchAndStore() {
 		}
 
 		if existingVideo == nil {
+			// Drop videos rejected by the ingestion filter rules
+			if rejection := vf.filterEngine.Evaluate(video); rejection != nil {
+				vf.recordRejection(video, rejection)
+				filtered++
+				continue
+			}
+
+			video.CategoryName = categoryNames[video.CategoryID]
+			video.SearchTerms = vf.synonyms.Tokens(video)
+
 			// FamPay Requirement: Store video with all required fields
 			if err := vf.videoRepo.Create(video); err != nil {
 				log.Printf("❌ Error storing video %s: %v", video.VideoID, err)
@@ -120,14 +186,31 @@ func (vf *VideoFetcher) fetchAndStore() {
 				continue
 			}
 			stored++
+			storedPerQuery[video.SearchQuery]++
+
+			for _, listener := range vf.listeners {
+				if err := listener.VideoStored(video); err != nil {
+					log.Printf("⚠️ Ingest listener failed for video %s: %v", video.VideoID, err)
+				}
+			}
+
+			// Optional: top comments for queries configured with QUERY_COMMENTS
+			if limit := vf.config.QueryCommentLimits[video.SearchQuery]; limit > 0 {
+				vf.fetchComments(video, limit)
+			}
+
+			// Optional: transcript for transcript-level search
+			if vf.config.CaptionsEnabled {
+				vf.fetchTranscript(video)
+			}
 		} else {
 			skipped++
 		}
 	}
 
 	duration := time.Since(startTime)
-	log.Printf("✅ Fetch cycle completed: %d stored, %d duplicates skipped, %d errors (took %v)",
-		stored, skipped, errors, duration)
+	log.Printf("✅ Fetch cycle completed: %d stored, %d duplicates skipped, %d filtered, %d errors (took %v)",
+		stored, skipped, filtered, errors, duration)
 
 	// Log API status for FamPay Bonus: Multiple API key management
 	if stored > 0 || errors > 0 {
@@ -136,3 +219,64 @@ func (vf *VideoFetcher) fetchAndStore() {
 			status["current_key_index"], status["working_keys"], status["total_keys"])
 	}
 }
+
+func (vf *VideoFetcher) fetchComments(video *models.Video, limit int) {
+	comments, err := vf.youtubeService.FetchTopComments(video.VideoID, limit)
+	if err != nil {
+		log.Printf("⚠️ Error fetching comments for video %s: %v", video.VideoID, err)
+		return
+	}
+
+	if err := vf.commentRepo.UpsertMany(comments); err != nil {
+		log.Printf("⚠️ Error storing comments for video %s: %v", video.VideoID, err)
+	}
+}
+
+func (vf *VideoFetcher) fetchTranscript(video *models.Video) {
+	language, segments, err := vf.captionSource.FetchTranscript(video.VideoID, vf.config.CaptionLanguages)
+	if err != nil {
+		log.Printf("⚠️ Error fetching captions for video %s: %v", video.VideoID, err)
+		return
+	}
+	if len(segments) == 0 {
+		return
+	}
+
+	if err := vf.transcriptRepo.ReplaceForVideo(video, language, segments); err != nil {
+		log.Printf("⚠️ Error storing transcript for video %s: %v", video.VideoID, err)
+		return
+	}
+	log.Printf("📝 Stored %d transcript segments (%s) for video %s", len(segments), language, video.VideoID)
+}
+
+func (vf *VideoFetcher) recordRejection(video *models.Video, rejection *services.Rejection) {
+	err := vf.filterEngine.RecordRejection(&models.RejectedVideo{
+		VideoID:      video.VideoID,
+		Title:        video.Title,
+		ChannelID:    video.ChannelID,
+		ChannelTitle: video.ChannelTitle,
+		SearchQuery:  video.SearchQuery,
+		RuleID:       rejection.Rule.ID,
+		RuleName:     rejection.Rule.Name,
+		RuleType:     rejection.Rule.Type,
+		Reason:       rejection.Reason,
+	})
+	if err != nil {
+		log.Printf("⚠️ Error recording rejected video %s: %v", video.VideoID, err)
+	}
+}
+
+// publishedAfter returns the lower bound for a query's next search
+func (vf *VideoFetcher) publishedAfter(query string) time.Time {
+	// FamPay Requirement: Fetch latest videos - use reasonable time window
+	publishedAfter := time.Now().Add(-2 * time.Hour)
+
+	// Get last published date for this query to avoid duplicates
+	lastVideo, err := vf.videoRepo.GetLatestForQuery(query)
+	if err == nil && lastVideo != nil && !lastVideo.PublishedAt.IsZero() {
+		// Use last published date but ensure we don't miss videos
+		publishedAfter = lastVideo.PublishedAt.Add(-5 * time.Minute)
+	}
+
+	return publishedAfter
+}
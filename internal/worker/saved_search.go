@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// Videos kept waiting while saved searches can't be loaded
+const maxPendingMatches = 10000
+
+// SavedSearchMatcher evaluates newly stored videos against every saved
+// search and records the matches. The fetcher reports stored videos as an
+// IngestListener; they are matched in batches, one query per saved search,
+// so matching uses the same Mongo semantics as /api/videos/search.
+type SavedSearchMatcher struct {
+	savedSearchRepo *repository.SavedSearchRepository
+	videoRepo       *repository.VideoRepository
+	synonyms        *services.SynonymService
+	interval        time.Duration
+	stopChan        chan struct{}
+
+	mutex   sync.Mutex
+	pending []primitive.ObjectID
+}
+
+func NewSavedSearchMatcher(savedSearchRepo *repository.SavedSearchRepository, videoRepo *repository.VideoRepository, synonyms *services.SynonymService, interval time.Duration) *SavedSearchMatcher {
+	return &SavedSearchMatcher{
+		savedSearchRepo: savedSearchRepo,
+		videoRepo:       videoRepo,
+		synonyms:        synonyms,
+		interval:        interval,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// VideoStored queues a new video for the next matching run
+func (sm *SavedSearchMatcher) VideoStored(video *models.Video) error {
+	sm.mutex.Lock()
+	sm.pending = append(sm.pending, video.ID)
+	sm.mutex.Unlock()
+	return nil
+}
+
+func (sm *SavedSearchMatcher) Start() {
+	log.Printf("🔔 Starting saved search matcher (every %v)", sm.interval)
+
+	ticker := time.NewTicker(sm.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sm.matchPending()
+		case <-sm.stopChan:
+			sm.matchPending()
+			log.Println("Saved search matcher stopped")
+			return
+		}
+	}
+}
+
+func (sm *SavedSearchMatcher) Stop() {
+	close(sm.stopChan)
+}
+
+func (sm *SavedSearchMatcher) matchPending() {
+	sm.mutex.Lock()
+	videoIDs := sm.pending
+	sm.pending = nil
+	sm.mutex.Unlock()
+
+	if len(videoIDs) == 0 {
+		return
+	}
+
+	savedSearches, err := sm.savedSearchRepo.List()
+	if err != nil {
+		log.Printf("❌ Error loading saved searches: %v", err)
+		sm.requeue(videoIDs)
+		return
+	}
+
+	total := 0
+	for i := range savedSearches {
+		savedSearch := &savedSearches[i]
+		query, filter, err := services.CompileSavedSearch(savedSearch, sm.synonyms)
+		if err != nil {
+			log.Printf("⚠️ Skipping saved search '%s': %v", savedSearch.Name, err)
+			continue
+		}
+
+		matched, err := sm.videoRepo.MatchingIDs(query, filter, videoIDs)
+		if err != nil {
+			log.Printf("❌ Error matching saved search '%s': %v", savedSearch.Name, err)
+			continue
+		}
+		if err := sm.savedSearchRepo.RecordMatches(savedSearch.ID, matched); err != nil {
+			log.Printf("❌ Error recording matches of saved search '%s': %v", savedSearch.Name, err)
+			continue
+		}
+		total += len(matched)
+	}
+
+	if total > 0 {
+		log.Printf("🔔 %d new videos produced %d saved search matches", len(videoIDs), total)
+	}
+}
+
+// requeue puts videos back when matching could not run at all
+func (sm *SavedSearchMatcher) requeue(videoIDs []primitive.ObjectID) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.pending = append(videoIDs, sm.pending...)
+	if dropped := len(sm.pending) - maxPendingMatches; dropped > 0 {
+		log.Printf("⚠️ Dropping %d queued videos from saved search matching", dropped)
+		sm.pending = sm.pending[dropped:]
+	}
+}
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+)
+
+type queryState struct {
+	query            string
+	mode             string
+	cron             *CronSchedule
+	interval         time.Duration
+	nextRun          time.Time
+	lastRun          time.Time
+	lastNewVideos    int
+	consecutiveEmpty int
+}
+
+// QueryScheduler decides when each search query is due for fetching
+type QueryScheduler struct {
+	mutex       sync.RWMutex
+	states      []*queryState
+	minInterval time.Duration
+	maxInterval time.Duration
+}
+
+func NewQueryScheduler(youtubeConfig config.YouTubeConfig) *QueryScheduler {
+	baseInterval := time.Duration(youtubeConfig.FetchInterval) * time.Second
+	minInterval := time.Duration(youtubeConfig.AdaptiveMinInterval) * time.Second
+	maxInterval := time.Duration(youtubeConfig.AdaptiveMaxInterval) * time.Second
+	if minInterval <= 0 {
+		minInterval = baseInterval
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+
+	qs := &QueryScheduler{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+	}
+
+	now := time.Now()
+	for _, query := range youtubeConfig.SearchQueries {
+		state := &queryState{
+			query:    query,
+			mode:     models.ScheduleModeInterval,
+			interval: baseInterval,
+			nextRun:  now, // Interval and adaptive queries run once on startup
+		}
+
+		spec := strings.TrimSpace(youtubeConfig.QuerySchedules[query])
+		switch {
+		case spec == "" || spec == models.ScheduleModeInterval:
+			// Default fixed interval
+		case spec == models.ScheduleModeAdaptive:
+			state.mode = models.ScheduleModeAdaptive
+			state.interval = minInterval
+		case strings.HasPrefix(spec, models.ScheduleModeCron+":"):
+			cronSchedule, err := ParseCron(strings.TrimPrefix(spec, models.ScheduleModeCron+":"))
+			if err != nil {
+				log.Printf("⚠️ Invalid schedule for query '%s': %v (falling back to %v interval)", query, err, baseInterval)
+				break
+			}
+			state.mode = models.ScheduleModeCron
+			state.cron = cronSchedule
+			state.interval = 0
+			state.nextRun = cronSchedule.Next(now) // Only at scheduled times
+		default:
+			log.Printf("⚠️ Unknown schedule %q for query '%s' (falling back to %v interval)", spec, query, baseInterval)
+		}
+
+		qs.states = append(qs.states, state)
+	}
+
+	return qs
+}
+
+// Due returns the queries whose next run is at or before now
+func (qs *QueryScheduler) Due(now time.Time) []string {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	var due []string
+	for _, state := range qs.states {
+		if !state.nextRun.IsZero() && !state.nextRun.After(now) {
+			due = append(due, state.query)
+		}
+	}
+	return due
+}
+
+// Record stores the outcome of a fetch and schedules the next run.
+// Adaptive queries halve their interval after a productive run and
+// double it after an empty one, bounded by the configured min/max.
+func (qs *QueryScheduler) Record(query string, newVideos int, now time.Time) {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	for _, state := range qs.states {
+		if state.query != query {
+			continue
+		}
+
+		state.lastRun = now
+		state.lastNewVideos = newVideos
+		if newVideos > 0 {
+			state.consecutiveEmpty = 0
+		} else {
+			state.consecutiveEmpty++
+		}
+
+		switch state.mode {
+		case models.ScheduleModeCron:
+			state.nextRun = state.cron.Next(now)
+		case models.ScheduleModeAdaptive:
+			if newVideos > 0 {
+				state.interval /= 2
+			} else {
+				state.interval *= 2
+			}
+			if state.interval < qs.minInterval {
+				state.interval = qs.minInterval
+			}
+			if state.interval > qs.maxInterval {
+				state.interval = qs.maxInterval
+			}
+			state.nextRun = now.Add(state.interval)
+		default:
+			state.nextRun = now.Add(state.interval)
+		}
+		return
+	}
+}
+
+// Snapshot returns the current schedule of every query
+func (qs *QueryScheduler) Snapshot() []models.QuerySchedule {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	schedules := make([]models.QuerySchedule, 0, len(qs.states))
+	for _, state := range qs.states {
+		schedule := models.QuerySchedule{
+			Query:                state.query,
+			Mode:                 state.mode,
+			IntervalSeconds:      int64(state.interval / time.Second),
+			NextRun:              state.nextRun,
+			LastNewVideos:        state.lastNewVideos,
+			ConsecutiveEmptyRuns: state.consecutiveEmpty,
+		}
+		if state.cron != nil {
+			schedule.Expression = state.cron.String()
+		}
+		if !state.lastRun.IsZero() {
+			lastRun := state.lastRun
+			schedule.LastRun = &lastRun
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
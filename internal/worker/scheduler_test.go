@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+)
+
+func TestQuerySchedulerStartup(t *testing.T) {
+	scheduler := NewQueryScheduler(config.YouTubeConfig{
+		SearchQueries: []string{"cricket", "football", "news", "tennis"},
+		QuerySchedules: map[string]string{
+			"football": "adaptive",
+			"news":     "cron:0 0 1 1 *",
+			"tennis":   "cron:not a schedule",
+		},
+		FetchInterval:       10,
+		AdaptiveMinInterval: 10,
+		AdaptiveMaxInterval: 3600,
+	})
+
+	// Cron queries wait for their first scheduled time; an invalid
+	// expression falls back to the interval
+	now := time.Now()
+	if got, want := scheduler.Due(now), []string{"cricket", "football", "tennis"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Due() on startup = %v, want %v", got, want)
+	}
+
+	newYear := time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, time.Local)
+	for _, schedule := range scheduler.Snapshot() {
+		if schedule.Query == "news" && !schedule.NextRun.Equal(newYear) {
+			t.Errorf("news NextRun = %v, want %v", schedule.NextRun, newYear)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"log"
+	"time"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+// TrendingFetcher snapshots YouTube's mostPopular chart for every
+// configured region and category
+type TrendingFetcher struct {
+	trendingRepo   *repository.TrendingRepository
+	youtubeService *services.YouTubeService
+	regions        []string
+	categories     []string
+	maxResults     int
+	fetchInterval  time.Duration
+	stopChan       chan struct{}
+}
+
+func NewTrendingFetcher(trendingRepo *repository.TrendingRepository, youtubeService *services.YouTubeService, youtubeConfig config.YouTubeConfig) *TrendingFetcher {
+	return &TrendingFetcher{
+		trendingRepo:   trendingRepo,
+		youtubeService: youtubeService,
+		regions:        youtubeConfig.TrendingRegions,
+		categories:     youtubeConfig.TrendingCategories,
+		maxResults:     youtubeConfig.TrendingMaxResults,
+		fetchInterval:  time.Duration(youtubeConfig.TrendingInterval) * time.Second,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+func (tf *TrendingFetcher) Start() {
+	log.Printf("📈 Starting trending fetcher: regions %v, categories %v (every %v)", tf.regions, tf.categories, tf.fetchInterval)
+
+	tf.fetchCharts()
+
+	ticker := time.NewTicker(tf.fetchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tf.fetchCharts()
+		case <-tf.stopChan:
+			log.Println("Trending fetcher stopped")
+			return
+		}
+	}
+}
+
+func (tf *TrendingFetcher) Stop() {
+	close(tf.stopChan)
+}
+
+func (tf *TrendingFetcher) fetchCharts() {
+	for _, region := range tf.regions {
+		for _, categoryID := range tf.categories {
+			if err := tf.fetchChart(region, categoryID); err != nil {
+				log.Printf("❌ Error fetching trending chart %s/%s: %v", region, categoryID, err)
+			}
+		}
+	}
+}
+
+func (tf *TrendingFetcher) fetchChart(region, categoryID string) error {
+	entries, err := tf.youtubeService.FetchTrending(region, categoryID, tf.maxResults)
+	if err != nil {
+		return err
+	}
+
+	previous, err := tf.trendingRepo.GetLatest(region, categoryID)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &models.TrendingSnapshot{
+		Region:     region,
+		CategoryID: categoryID,
+		FetchedAt:  time.Now(),
+		Entries:    entries,
+	}
+
+	applyRankMovement(snapshot, previous)
+
+	if err := tf.trendingRepo.Create(snapshot); err != nil {
+		return err
+	}
+
+	log.Printf("📈 Stored trending chart %s/%s with %d videos", region, categoryID, len(entries))
+	return nil
+}
+
+// applyRankMovement compares a snapshot's entries with the previous
+// snapshot of the same chart. Entries are only new when there was a
+// previous snapshot to be missing from.
+func applyRankMovement(snapshot, previous *models.TrendingSnapshot) {
+	previousRanks := make(map[string]int)
+	if previous != nil {
+		snapshot.PreviousFetchedAt = &previous.FetchedAt
+		for _, entry := range previous.Entries {
+			previousRanks[entry.VideoID] = entry.Rank
+		}
+	}
+	for i := range snapshot.Entries {
+		entry := &snapshot.Entries[i]
+		if rank, ok := previousRanks[entry.VideoID]; ok {
+			entry.PreviousRank = rank
+			entry.Movement = rank - entry.Rank
+		} else {
+			entry.IsNew = previous != nil
+		}
+	}
+}
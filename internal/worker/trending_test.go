@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"fampay-youtube-api/internal/models"
+)
+
+func TestApplyRankMovement(t *testing.T) {
+	chart := func(videoIDs ...string) *models.TrendingSnapshot {
+		snapshot := &models.TrendingSnapshot{Region: "IN", CategoryID: models.TrendingAllCategories, FetchedAt: time.Now()}
+		for i, videoID := range videoIDs {
+			snapshot.Entries = append(snapshot.Entries, models.TrendingEntry{Rank: i + 1, VideoID: videoID})
+		}
+		return snapshot
+	}
+
+	type movement struct {
+		previousRank int
+		movement     int
+		isNew        bool
+	}
+
+	tests := []struct {
+		name     string
+		previous *models.TrendingSnapshot
+		current  *models.TrendingSnapshot
+		want     map[string]movement
+	}{
+		{
+			// Nothing to compare with, so nothing is new
+			name:    "first snapshot",
+			current: chart("a", "b"),
+			want:    map[string]movement{"a": {}, "b": {}},
+		},
+		{
+			name:     "movement",
+			previous: chart("a", "b", "c"),
+			current:  chart("c", "a", "d"),
+			want: map[string]movement{
+				"c": {previousRank: 3, movement: 2},
+				"a": {previousRank: 1, movement: -1},
+				"d": {isNew: true},
+			},
+		},
+		{
+			name:     "unchanged",
+			previous: chart("a", "b"),
+			current:  chart("a", "b"),
+			want:     map[string]movement{"a": {previousRank: 1}, "b": {previousRank: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyRankMovement(tt.current, tt.previous)
+
+			for _, entry := range tt.current.Entries {
+				got := movement{entry.PreviousRank, entry.Movement, entry.IsNew}
+				if got != tt.want[entry.VideoID] {
+					t.Errorf("%s: got %+v, want %+v", entry.VideoID, got, tt.want[entry.VideoID])
+				}
+			}
+			if (tt.current.PreviousFetchedAt != nil) != (tt.previous != nil) {
+				t.Errorf("PreviousFetchedAt = %v, want it set only after a previous snapshot", tt.current.PreviousFetchedAt)
+			}
+		})
+	}
+}
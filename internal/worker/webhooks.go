@@ -0,0 +1,328 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/repository"
+	"fampay-youtube-api/internal/services"
+)
+
+const (
+	// How long the enabled webhook list is used before reloading, so edits
+	// made through another instance's admin API reach this one
+	webhookReloadInterval = time.Minute
+
+	// Deliveries sent at once, and claimed per run
+	webhookConcurrency     = 4
+	maxDeliveriesPerRun    = 200
+	maxWebhookErrorSnippet = 256
+)
+
+// WebhookDispatcher queues a delivery for every enabled webhook whose
+// filters match a newly stored video, then sends due deliveries with
+// signed requests. Failed attempts are retried with exponential backoff;
+// the queue lives in MongoDB so retries survive restarts.
+type WebhookDispatcher struct {
+	webhookRepo *repository.WebhookRepository
+	config      config.WebhookConfig
+	client      *http.Client
+	stopChan    chan struct{}
+
+	mutex    sync.RWMutex
+	webhooks []models.Webhook
+	loadedAt time.Time
+}
+
+// webhookPayload is the JSON body of every webhook request
+type webhookPayload struct {
+	ID        string      `json:"id"` // Delivery ID, for deduplicating retries
+	Event     string      `json:"event"`
+	WebhookID string      `json:"webhook_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+func NewWebhookDispatcher(webhookRepo *repository.WebhookRepository, cfg config.WebhookConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		config:      cfg,
+		client: &http.Client{
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+			// A redirected POST would be replayed as a GET, so treat it as a failure
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Reload fetches the enabled webhooks from the database
+func (wd *WebhookDispatcher) Reload() error {
+	webhooks, err := wd.webhookRepo.ListEnabled()
+	if err != nil {
+		return err
+	}
+
+	wd.mutex.Lock()
+	wd.webhooks = webhooks
+	wd.loadedAt = time.Now()
+	wd.mutex.Unlock()
+
+	return nil
+}
+
+// enabled returns the enabled webhooks, reloading them once stale. A failed
+// reload keeps the previous list.
+func (wd *WebhookDispatcher) enabled() []models.Webhook {
+	wd.mutex.RLock()
+	webhooks, loadedAt := wd.webhooks, wd.loadedAt
+	wd.mutex.RUnlock()
+
+	if time.Since(loadedAt) < webhookReloadInterval {
+		return webhooks
+	}
+	if err := wd.Reload(); err != nil {
+		log.Printf("⚠️ Failed to reload webhooks: %v", err)
+		wd.mutex.Lock()
+		wd.loadedAt = time.Now() // Retry after the next interval
+		wd.mutex.Unlock()
+		return webhooks
+	}
+
+	wd.mutex.RLock()
+	defer wd.mutex.RUnlock()
+	return wd.webhooks
+}
+
+// VideoStored queues a video.created delivery for every matching webhook.
+// It satisfies the fetcher's IngestListener interface.
+func (wd *WebhookDispatcher) VideoStored(video *models.Video) error {
+	webhooks := wd.enabled()
+
+	var deliveries []*models.WebhookDelivery
+	for i := range webhooks {
+		if !services.WebhookMatches(&webhooks[i], video) {
+			continue
+		}
+		delivery, err := newDelivery(webhooks[i].ID, models.WebhookEventVideoCreated, video)
+		if err != nil {
+			return err
+		}
+		delivery.VideoID = video.VideoID
+		deliveries = append(deliveries, delivery)
+	}
+
+	return wd.webhookRepo.EnqueueDeliveries(deliveries)
+}
+
+// SendPing queues a ping delivery, which is sent even to a disabled webhook
+// so a fixed receiver can be checked before re-enabling it
+func (wd *WebhookDispatcher) SendPing(webhook *models.Webhook) (*models.WebhookDelivery, error) {
+	delivery, err := newDelivery(webhook.ID, models.WebhookEventPing, map[string]string{"name": webhook.Name})
+	if err != nil {
+		return nil, err
+	}
+	if err := wd.webhookRepo.EnqueueDeliveries([]*models.WebhookDelivery{delivery}); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func newDelivery(webhookID primitive.ObjectID, event string, data interface{}) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: webhookID,
+		Event:     event,
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:        delivery.ID.Hex(),
+		Event:     event,
+		WebhookID: webhookID.Hex(),
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	delivery.Payload = string(body)
+
+	return delivery, nil
+}
+
+func (wd *WebhookDispatcher) Start() {
+	interval := time.Duration(wd.config.DeliveryInterval) * time.Second
+	log.Printf("📤 Starting webhook dispatcher (every %v, %d attempts per delivery)", interval, wd.config.MaxAttempts)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			wd.deliverDue()
+		case <-wd.stopChan:
+			log.Println("Webhook dispatcher stopped")
+			return
+		}
+	}
+}
+
+func (wd *WebhookDispatcher) Stop() {
+	close(wd.stopChan)
+}
+
+// deliverDue sends the deliveries whose next attempt is due
+func (wd *WebhookDispatcher) deliverDue() {
+	// Long enough for the attempt and recording its outcome
+	lease := time.Duration(wd.config.Timeout)*time.Second + time.Minute
+
+	sem := make(chan struct{}, webhookConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < maxDeliveriesPerRun; i++ {
+		delivery, err := wd.webhookRepo.ClaimDueDelivery(lease)
+		if err != nil {
+			log.Printf("❌ Error claiming webhook delivery: %v", err)
+			break
+		}
+		if delivery == nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			wd.deliver(delivery)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+func (wd *WebhookDispatcher) deliver(delivery *models.WebhookDelivery) {
+	webhook, err := wd.webhookRepo.GetByID(delivery.WebhookID)
+	if err != nil {
+		log.Printf("❌ Error loading webhook for delivery %s: %v", delivery.ID.Hex(), err)
+		return // Retried once the claim expires
+	}
+	if webhook == nil || (!webhook.Enabled && delivery.Event != models.WebhookEventPing) {
+		attempt := models.WebhookAttempt{AttemptedAt: time.Now(), Error: "webhook deleted or disabled"}
+		if err := wd.webhookRepo.RecordAttempt(delivery.ID, attempt, models.DeliveryStatusFailed, nil); err != nil {
+			log.Printf("❌ Error recording webhook attempt: %v", err)
+		}
+		return
+	}
+
+	attempt := wd.send(webhook, delivery)
+
+	if attempt.Error == "" {
+		if err := wd.webhookRepo.RecordAttempt(delivery.ID, attempt, models.DeliveryStatusDelivered, nil); err != nil {
+			log.Printf("❌ Error recording webhook attempt: %v", err)
+		}
+		if err := wd.webhookRepo.RecordSuccess(webhook.ID); err != nil {
+			log.Printf("❌ Error updating webhook '%s': %v", webhook.Name, err)
+		}
+		return
+	}
+
+	attempts := len(delivery.Attempts) + 1
+	if attempts >= wd.config.MaxAttempts {
+		log.Printf("⚠️ Webhook '%s' delivery %s failed after %d attempts: %s", webhook.Name, delivery.ID.Hex(), attempts, attempt.Error)
+		err = wd.webhookRepo.RecordAttempt(delivery.ID, attempt, models.DeliveryStatusFailed, nil)
+	} else {
+		next := time.Now().Add(wd.retryDelay(attempts))
+		err = wd.webhookRepo.RecordAttempt(delivery.ID, attempt, models.DeliveryStatusPending, &next)
+	}
+	if err != nil {
+		log.Printf("❌ Error recording webhook attempt: %v", err)
+	}
+
+	disabled, err := wd.webhookRepo.RecordFailure(webhook.ID, wd.config.DisableAfter)
+	if err != nil {
+		log.Printf("❌ Error updating webhook '%s': %v", webhook.Name, err)
+		return
+	}
+	if disabled {
+		failed, err := wd.webhookRepo.FailPending(webhook.ID)
+		if err != nil {
+			log.Printf("❌ Error failing queued deliveries of webhook '%s': %v", webhook.Name, err)
+		}
+		log.Printf("🚫 Disabled webhook '%s' after %d consecutive failures (%d queued deliveries dropped)", webhook.Name, wd.config.DisableAfter, failed)
+		if err := wd.Reload(); err != nil {
+			log.Printf("⚠️ Failed to reload webhooks: %v", err)
+		}
+	}
+}
+
+// send makes one signed request and describes its outcome. Any 2xx
+// response counts as delivered.
+func (wd *WebhookDispatcher) send(webhook *models.Webhook, delivery *models.WebhookDelivery) models.WebhookAttempt {
+	start := time.Now()
+	attempt := models.WebhookAttempt{AttemptedAt: start}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "fampay-youtube-api-webhooks/1.0")
+	req.Header.Set(services.WebhookEventHeader, delivery.Event)
+	req.Header.Set(services.WebhookDeliveryHeader, delivery.ID.Hex())
+	req.Header.Set(services.WebhookTimestampHeader, fmt.Sprint(start.Unix()))
+	req.Header.Set(services.WebhookSignatureHeader, services.SignWebhookPayload(webhook.Secret, start.Unix(), body))
+
+	resp, err := wd.client.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorSnippet))
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Lets the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if text := strings.TrimSpace(string(snippet)); text != "" {
+			attempt.Error += ": " + text
+		}
+	}
+	return attempt
+}
+
+// retryDelay is the wait before retry n (1-based): the base delay doubled
+// per earlier retry, capped, with up to 20% jitter so failed deliveries
+// don't all retry at once
+func (wd *WebhookDispatcher) retryDelay(n int) time.Duration {
+	delay := time.Duration(wd.config.RetryBaseDelay) * time.Second
+	maxDelay := time.Duration(wd.config.RetryMaxDelay) * time.Second
+	for i := 1; i < n && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if jitter := int64(delay) / 5; jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter))
+	}
+	return delay
+}
@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fampay-youtube-api/internal/config"
+	"fampay-youtube-api/internal/models"
+	"fampay-youtube-api/internal/services"
+)
+
+const testWebhookSecret = "whsec_test_secret_1234"
+
+func newTestDispatcher() *WebhookDispatcher {
+	return NewWebhookDispatcher(nil, config.WebhookConfig{
+		Timeout:        5,
+		MaxAttempts:    8,
+		RetryBaseDelay: 30,
+		RetryMaxDelay:  3600,
+	})
+}
+
+func TestWebhookSendSignsRequest(t *testing.T) {
+	webhookID := primitive.NewObjectID()
+	delivery, err := newDelivery(webhookID, models.WebhookEventPing, map[string]string{"message": "hello"})
+	if err != nil {
+		t.Fatalf("newDelivery: %v", err)
+	}
+
+	var deliveryIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		if got := r.Header.Get(services.WebhookEventHeader); got != models.WebhookEventPing {
+			t.Errorf("%s = %q, want %q", services.WebhookEventHeader, got, models.WebhookEventPing)
+		}
+		deliveryIDs = append(deliveryIDs, r.Header.Get(services.WebhookDeliveryHeader))
+
+		signature := r.Header.Get(services.WebhookSignatureHeader)
+		timestamp := r.Header.Get(services.WebhookTimestampHeader)
+		if err := services.VerifyWebhookSignature(testWebhookSecret, signature, timestamp, body, time.Minute); err != nil {
+			t.Errorf("VerifyWebhookSignature: %v", err)
+		}
+
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload %q: %v", body, err)
+		}
+		if payload.ID != delivery.ID.Hex() || payload.WebhookID != webhookID.Hex() || payload.Event != models.WebhookEventPing {
+			t.Errorf("payload = %+v, want delivery %s of webhook %s", payload, delivery.ID.Hex(), webhookID.Hex())
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher := newTestDispatcher()
+	webhook := &models.Webhook{ID: webhookID, URL: server.URL, Secret: testWebhookSecret}
+
+	// A retry is signed again but keeps the delivery ID, so receivers can deduplicate
+	for i := 0; i < 2; i++ {
+		attempt := dispatcher.send(webhook, delivery)
+		if attempt.Error != "" || attempt.StatusCode != http.StatusNoContent {
+			t.Errorf("attempt %d = %+v, want a delivered 204", i+1, attempt)
+		}
+	}
+	if len(deliveryIDs) != 2 || deliveryIDs[0] != delivery.ID.Hex() || deliveryIDs[1] != delivery.ID.Hex() {
+		t.Errorf("%s headers = %q, want %s twice", services.WebhookDeliveryHeader, deliveryIDs, delivery.ID.Hex())
+	}
+}
+
+func TestWebhookSendFailures(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "accepted",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusAccepted) },
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "database unavailable", http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantError:  "HTTP 500: database unavailable",
+		},
+		{
+			name:       "gone",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusGone) },
+			wantStatus: http.StatusGone,
+			wantError:  "HTTP 410",
+		},
+		{
+			// A redirected POST would be replayed as a GET, so it isn't followed
+			name: "redirect",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Location", target.URL)
+				w.WriteHeader(http.StatusFound)
+			},
+			wantStatus: http.StatusFound,
+			wantError:  "HTTP 302",
+		},
+	}
+
+	dispatcher := newTestDispatcher()
+	delivery, err := newDelivery(primitive.NewObjectID(), models.WebhookEventVideoCreated, nil)
+	if err != nil {
+		t.Fatalf("newDelivery: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			attempt := dispatcher.send(&models.Webhook{URL: server.URL, Secret: testWebhookSecret}, delivery)
+			if attempt.StatusCode != tt.wantStatus || attempt.Error != tt.wantError {
+				t.Errorf("attempt = %d %q, want %d %q", attempt.StatusCode, attempt.Error, tt.wantStatus, tt.wantError)
+			}
+		})
+	}
+	if redirected {
+		t.Error("redirect was followed")
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		attempt := dispatcher.send(&models.Webhook{URL: url}, delivery)
+		if attempt.Error == "" || attempt.StatusCode != 0 {
+			t.Errorf("attempt = %+v, want a transport error", attempt)
+		}
+	})
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	dispatcher := newTestDispatcher()
+
+	tests := []struct {
+		retry int
+		base  time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		// Doubling again would pass the one hour cap
+		{8, time.Hour},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		// Up to 20% jitter is added on top of the backoff
+		for i := 0; i < 100; i++ {
+			if got := dispatcher.retryDelay(tt.retry); got < tt.base || got >= tt.base+tt.base/5 {
+				t.Fatalf("retryDelay(%d) = %v, want within [%v, %v)", tt.retry, got, tt.base, tt.base+tt.base/5)
+			}
+		}
+	}
+}
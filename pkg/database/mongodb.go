@@ -51,22 +51,53 @@ func createIndexes(db *mongo.Database) error {
 	// Create indexes for performance
 	indexes := []mongo.IndexModel{
 		{
-			Keys:    bson.D{{"video_id", 1}},
+			Keys:    bson.D{{Key: "video_id", Value: 1}},
 			Options: options.Index().SetUnique(true),
 		},
 		{
-			Keys: bson.D{{"published_at", -1}},
-		},
-		{
-			Keys: bson.D{{"title", "text"}, {"description", "text"}},
+			// Listing sort with the _id tie-breaker used by cursor pagination
+			Keys: bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
 		},
 		{
 			Keys: bson.D{
-				{"published_at", -1},
-				{"title", 1},
-				{"description", 1},
+				{Key: "published_at", Value: -1},
+				{Key: "title", Value: 1},
+				{Key: "description", Value: 1},
 			},
 		},
+		{
+			// Latest video per search query (per-query fetch schedules, ?search_query=)
+			Keys: bson.D{{Key: "search_query", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Language filter (?lang=)
+			Keys: bson.D{{Key: "lang", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Ingestion time filters (?ingested_after=)
+			Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Channel pages (/api/channels/:id/videos)
+			Keys: bson.D{{Key: "channel_id", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Format rails (?format=short)
+			Keys: bson.D{{Key: "format", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Typo-tolerant title search (?fuzzy=true)
+			Keys: bson.D{{Key: "title_ngrams", Value: 1}},
+		},
+		{
+			// Category filter (?category=17)
+			Keys: bson.D{{Key: "category_id", Value: 1}, {Key: "published_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			// Live and upcoming broadcasts (sparse: most videos are not broadcasts)
+			Keys:    bson.D{{Key: "broadcast.state", Value: 1}, {Key: "search_query", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
 	}
 
 	_, err := videosCollection.Indexes().CreateMany(ctx, indexes)
@@ -74,6 +105,234 @@ func createIndexes(db *mongo.Database) error {
 		return fmt.Errorf("failed to create indexes: %w", err)
 	}
 
+	if err := ensureTextIndex(ctx, videosCollection); err != nil {
+		return err
+	}
+
+	if err := dropSupersededIndexes(ctx, videosCollection); err != nil {
+		return err
+	}
+
+	// Channel entities
+	_, err = db.Collection("channels").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "channel_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "subscriber_count", Value: -1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create channel indexes: %w", err)
+	}
+
+	// Video category catalog per region
+	_, err = db.Collection("video_categories").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "region", Value: 1}, {Key: "category_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create video category index: %w", err)
+	}
+
+	// Trending chart snapshots (kept for 30 days)
+	_, err = db.Collection("trending_snapshots").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "region", Value: 1}, {Key: "category_id", Value: 1}, {Key: "fetched_at", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "fetched_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(30 * 24 * 3600),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create trending indexes: %w", err)
+	}
+
+	// Comment threads of stored videos
+	_, err = db.Collection("comments").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "comment_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "video_id", Value: 1}, {Key: "rank", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comment indexes: %w", err)
+	}
+
+	// Transcript segments for ?in=transcript search
+	_, err = db.Collection("transcript_segments").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "video_id", Value: 1}, {Key: "start", Value: 1}},
+		},
+		{
+			// Captions come in many languages, so words are indexed as
+			// written; "language" holds the caption language, not a
+			// stemming override
+			Keys:    bson.D{{Key: "normalized_text", Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none").SetLanguageOverride("text_language"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create transcript indexes: %w", err)
+	}
+
+	// Ingestion filter rejection log
+	_, err = db.Collection("rejected_videos").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "rule_id", Value: 1}, {Key: "last_seen_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "last_seen_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rejected video indexes: %w", err)
+	}
+
+	// Saved search matches, kept for 30 days
+	_, err = db.Collection("saved_search_matches").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "saved_search_id", Value: 1}, {Key: "video_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "saved_search_id", Value: 1}, {Key: "matched_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "matched_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(30 * 24 * 3600),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create saved search match indexes: %w", err)
+	}
+
+	// Webhook delivery queue and log, kept for 14 days
+	_, err = db.Collection("webhook_deliveries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(14 * 24 * 3600),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery indexes: %w", err)
+	}
+
 	log.Println("MongoDB indexes created successfully")
 	return nil
 }
+
+// Weights of the fields in the videos text index (title matches rank higher;
+// search_terms holds the markers of synonym groups found in the video)
+var textIndexWeights = bson.D{{Key: "title", Value: 10}, {Key: "description", Value: 2}, {Key: "search_terms", Value: 5}}
+
+// Video indexes replaced by one with more keys, usually the _id
+// tie-breaker of cursor pagination. The new index serves every query the
+// old one did, so keeping both only slows down writes.
+var supersededIndexes = map[string]bool{
+	"published_at_-1":                true,
+	"channel_id_1_published_at_-1":   true,
+	"format_1_published_at_-1":       true,
+	"category_id_1_published_at_-1":  true,
+	"search_query_1_published_at_-1": true,
+}
+
+// dropSupersededIndexes removes superseded indexes left by earlier versions
+func dropSupersededIndexes(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list indexes: %w", err)
+	}
+
+	var existing []bson.M
+	if err := cursor.All(ctx, &existing); err != nil {
+		return fmt.Errorf("failed to decode indexes: %w", err)
+	}
+
+	for _, index := range existing {
+		name, _ := index["name"].(string)
+		if !supersededIndexes[name] {
+			continue
+		}
+
+		log.Printf("🔁 Dropping index %s, superseded by an index with more keys", name)
+		if _, err := collection.Indexes().DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureTextIndex creates the weighted title/description text index. A
+// collection can only have one text index, so an existing one with other
+// fields or weights (such as the original unweighted index) is dropped first.
+func ensureTextIndex(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list indexes: %w", err)
+	}
+
+	var existing []bson.M
+	if err := cursor.All(ctx, &existing); err != nil {
+		return fmt.Errorf("failed to decode indexes: %w", err)
+	}
+
+	for _, index := range existing {
+		weights, ok := index["weights"].(bson.M)
+		if !ok {
+			continue // Not a text index
+		}
+		if textWeightsMatch(weights) {
+			return nil
+		}
+
+		name, _ := index["name"].(string)
+		log.Printf("🔁 Replacing text index %s with weighted title/description index", name)
+		if _, err := collection.Indexes().DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop text index %s: %w", name, err)
+		}
+	}
+
+	keys := bson.D{}
+	for _, weight := range textIndexWeights {
+		keys = append(keys, bson.E{Key: weight.Key, Value: "text"})
+	}
+
+	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetWeights(textIndexWeights).SetName("title_description_text"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create text index: %w", err)
+	}
+
+	return nil
+}
+
+func textWeightsMatch(weights bson.M) bool {
+	if len(weights) != len(textIndexWeights) {
+		return false
+	}
+	for _, expected := range textIndexWeights {
+		if fmt.Sprint(weights[expected.Key]) != fmt.Sprint(expected.Value) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,11 +1,11 @@
 import { useState, useEffect } from 'react'
-import { useQuery } from '@tanstack/react-query'
+import { useQuery, useQueryClient } from '@tanstack/react-query'
 import { Search, RefreshCw, Video, Globe, Database } from 'lucide-react'
 import VideoGrid from './VideoGrid'
 import SearchBar from './SearchBar'
 import Pagination from './Pagination'
 import LoadingSpinner from './LoadingSpinner'
-import { fetchVideos, searchVideos, searchYouTubeLive } from '../services/api'
+import { fetchVideos, searchVideos, searchYouTubeLive, openVideoStream } from '../services/api'
 
 export default function Dashboard() {
   const [currentPage, setCurrentPage] = useState(1)
@@ -38,6 +38,26 @@ export default function Dashboard() {
     staleTime: 2 * 60 * 1000, // 2 minutes
   })
 
+  // Refresh the first page of the stored list as new videos are stored.
+  // A fetch cycle stores videos in bursts, so refreshes are debounced.
+  const queryClient = useQueryClient()
+  const isLiveList = !isSearchMode && currentPage === 1 && sortBy === 'latest'
+  useEffect(() => {
+    if (!isLiveList) return
+
+    let timer
+    const refresh = () => {
+      clearTimeout(timer)
+      timer = setTimeout(() => queryClient.invalidateQueries({ queryKey: ['videos'] }), 1000)
+    }
+    const source = openVideoStream(refresh, refresh)
+
+    return () => {
+      clearTimeout(timer)
+      source.close()
+    }
+  }, [isLiveList, queryClient])
+
   const handleSearch = (query) => {
     setSearchQuery(query)
     setIsSearchMode(true)
@@ -57,6 +57,15 @@ export const fetchVideos = async (page = 1, pageSize = 12, sortBy = 'latest') =>
   }
 }
 
+// Live feed of newly stored videos (Server-Sent Events). EventSource
+// reconnects on its own and resumes from the last event it received.
+export const openVideoStream = (onVideo, onReset) => {
+  const source = new EventSource(`${API_BASE_URL}/api/videos/stream`)
+  source.addEventListener('video', (event) => onVideo(JSON.parse(event.data)))
+  source.addEventListener('reset', () => onReset?.())
+  return source
+}
+
 export const getHealthStatus = async () => {
   try {
     const response = await api.get('/health')